@@ -4,15 +4,20 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"os"
 	"os/exec"
 	"strconv"
 	"strings"
 
 	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/term"
 	"github.com/spf13/cobra"
 )
 
-var repoFlag string
+var (
+	repoFlag    string
+	addJSONFlag bool
+)
 
 var addCmd = &cobra.Command{
 	Use:   "add <parent-issue> <sub-issue>",
@@ -27,7 +32,10 @@ Examples:
   gh sub-issues add https://github.com/owner/repo/issues/123 456
   
   # Cross-repository linking
-  gh sub-issues add 123 456 --repo owner/repo`,
+  gh sub-issues add 123 456 --repo owner/repo
+  
+  # Machine-readable result
+  gh sub-issues add 123 456 --json`,
 	Args: cobra.ExactArgs(2),
 	RunE: runAdd,
 }
@@ -38,6 +46,7 @@ func init() {
 	
 	// Add flags
 	addCmd.Flags().StringVarP(&repoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+	addCmd.Flags().BoolVar(&addJSONFlag, "json", false, "Output the result as JSON")
 }
 
 // IssueReference represents a parsed issue reference
@@ -108,13 +117,26 @@ func parseIssueURL(url string) (*IssueReference, error) {
 	}, nil
 }
 
-// getIssueNodeID gets the GraphQL node ID for an issue
-func getIssueNodeID(client *api.GraphQLClient, owner, repo string, number int) (string, error) {
+// IssueInfo holds basic information about an issue
+type IssueInfo struct {
+	ID     string `json:"id"`
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	URL    string `json:"url"`
+	State  string `json:"state"`
+}
+
+// getIssue gets basic information about an issue
+func getIssue(client *api.GraphQLClient, owner, repo string, number int) (*IssueInfo, error) {
 	query := `
 		query($owner: String!, $repo: String!, $number: Int!) {
 			repository(owner: $owner, name: $repo) {
 				issue(number: $number) {
 					id
+					number
+					title
+					url
+					state
 				}
 			}
 		}`
@@ -127,22 +149,31 @@ func getIssueNodeID(client *api.GraphQLClient, owner, repo string, number int) (
 	
 	var response struct {
 		Repository struct {
-			Issue struct {
-				ID string `json:"id"`
-			} `json:"issue"`
+			Issue IssueInfo `json:"issue"`
 		} `json:"repository"`
 	}
 	
 	err := client.Do(query, variables, &response)
 	if err != nil {
-		return "", fmt.Errorf("failed to get issue #%d: %w", number, err)
+		return nil, fmt.Errorf("failed to get issue #%d: %w", number, err)
 	}
 	
 	if response.Repository.Issue.ID == "" {
-		return "", fmt.Errorf("issue #%d not found in %s/%s", number, owner, repo)
+		return nil, fmt.Errorf("issue #%d not found in %s/%s", number, owner, repo)
 	}
 	
-	return response.Repository.Issue.ID, nil
+	issue := response.Repository.Issue
+	issue.State = strings.ToLower(issue.State)
+	return &issue, nil
+}
+
+// getIssueNodeID gets the GraphQL node ID for an issue
+func getIssueNodeID(client *api.GraphQLClient, owner, repo string, number int) (string, error) {
+	issue, err := getIssue(client, owner, repo, number)
+	if err != nil {
+		return "", err
+	}
+	return issue.ID, nil
 }
 
 // addSubIssue links a sub-issue to a parent issue
@@ -182,7 +213,7 @@ func addSubIssue(client *api.GraphQLClient, parentID, subIssueID string) (int, i
 		} `json:"addSubIssue"`
 	}
 	
-	err := client.Do(mutation, variables, &response)
+	err := doMutation(client, "addSubIssue", "Link sub-issue to parent issue", mutation, variables, &response)
 	if err != nil {
 		return 0, 0, fmt.Errorf("failed to add sub-issue: %w", err)
 	}
@@ -269,7 +300,7 @@ func runAdd(cmd *cobra.Command, args []string) error {
 	fmt.Fprintf(cmd.OutOrStderr(), "Getting parent issue #%d from %s/%s...\n", 
 		parentRef.Number, parentRef.Owner, parentRef.Repo)
 	
-	parentID, err := getIssueNodeID(client, parentRef.Owner, parentRef.Repo, parentRef.Number)
+	parent, err := getIssue(client, parentRef.Owner, parentRef.Repo, parentRef.Number)
 	if err != nil {
 		// Check if it's an authentication error
 		if strings.Contains(err.Error(), "authentication") || strings.Contains(err.Error(), "401") {
@@ -286,7 +317,7 @@ func runAdd(cmd *cobra.Command, args []string) error {
 	fmt.Fprintf(cmd.OutOrStderr(), "Getting sub-issue #%d from %s/%s...\n", 
 		subRef.Number, subRef.Owner, subRef.Repo)
 	
-	subID, err := getIssueNodeID(client, subRef.Owner, subRef.Repo, subRef.Number)
+	sub, err := getIssue(client, subRef.Owner, subRef.Repo, subRef.Number)
 	if err != nil {
 		// Check if it's a permission error
 		if strings.Contains(err.Error(), "permission") || strings.Contains(err.Error(), "403") {
@@ -298,19 +329,36 @@ func runAdd(cmd *cobra.Command, args []string) error {
 	
 	// Link the issues
 	fmt.Fprintf(cmd.OutOrStderr(), "Linking issues...\n")
-	parentNum, subNum, err := addSubIssue(client, parentID, subID)
+	result := &MutationResult{Parent: newIssueResult(parentRef, parent)}
+	parentNum, subNum, err := addSubIssue(client, parent.ID, sub.ID)
 	if err != nil {
 		// Check for specific error cases
 		if strings.Contains(err.Error(), "permission") || strings.Contains(err.Error(), "403") {
-			return fmt.Errorf("insufficient permissions to modify issues in this repository")
-		}
-		if strings.Contains(err.Error(), "already") {
-			return fmt.Errorf("issue #%d is already a sub-issue of #%d", 
+			err = fmt.Errorf("insufficient permissions to modify issues in this repository")
+		} else if strings.Contains(err.Error(), "already") {
+			err = fmt.Errorf("issue #%d is already a sub-issue of #%d", 
 				subRef.Number, parentRef.Number)
 		}
+		if addJSONFlag {
+			result.SubIssues = append(result.SubIssues, failedIssueResult(subRef, sub, err))
+			if writeErr := writeMutationResult(cmd.OutOrStdout(), result); writeErr != nil {
+				return writeErr
+			}
+		}
 		return err
 	}
 	
+	if dryRunFlag {
+		return writePlan(cmd.OutOrStdout(), addJSONFlag || !term.IsTerminal(os.Stdout))
+	}
+	
+	if addJSONFlag {
+		subResult := newIssueResult(subRef, sub)
+		subResult.Status = statusAdded
+		result.SubIssues = append(result.SubIssues, subResult)
+		return writeMutationResult(cmd.OutOrStdout(), result)
+	}
+	
 	// Success message
 	fmt.Fprintf(cmd.OutOrStdout(), "✓ Added issue #%d as a sub-issue of #%d\n", subNum, parentNum)
 	
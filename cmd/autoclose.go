@@ -0,0 +1,350 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/spf13/cobra"
+)
+
+var (
+	autocloseRepoFlag  string
+	autocloseUpFlag    bool
+	autocloseForceFlag bool
+)
+
+var autocloseCmd = &cobra.Command{
+	Use:   "autoclose [<parent-issue>]",
+	Short: "Close parent issues whose sub-issues are all closed",
+	Long: `Close open parent issues once all of their sub-issues are closed, leaving a
+comment that lists the sub-issues.
+
+With a parent issue, that issue and the parents below it are checked. Without
+one, every open issue of the repository is checked and the issues found are
+closed after confirmation unless --force is given. With --up, the ancestors of
+closed issues are closed as well once all of their sub-issues are closed; they
+are found before anything is closed and listed for confirmation with the rest.
+
+Examples:
+  # Close #123 and any parents below it whose sub-issues are done
+  gh sub-issue autoclose 123
+
+  # Check every open issue of a repository and close grandparents too
+  gh sub-issue autoclose --repo owner/repo --up
+
+  # Only report the candidates
+  gh sub-issue autoclose --repo owner/repo --dry-run`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runAutoclose,
+}
+
+func init() {
+	rootCmd.AddCommand(autocloseCmd)
+	autocloseCmd.Flags().StringVarP(&autocloseRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+	autocloseCmd.Flags().BoolVar(&autocloseUpFlag, "up", false, "Also close ancestors once all of their sub-issues are closed")
+	autocloseCmd.Flags().BoolVarP(&autocloseForceFlag, "force", "f", false, "Skip confirmation prompt when checking the whole repository")
+}
+
+// allChildrenClosed reports whether every sub-issue of node is closed or in closed
+func allChildrenClosed(node *IssueNode, closed map[string]bool) bool {
+	// Sub-issues beyond the fetched ones are unknown
+	if len(node.Children) == 0 || len(node.Children) < node.subIssueCount {
+		return false
+	}
+	for _, child := range node.Children {
+		if child.State != "closed" && !closed[child.ID] {
+			return false
+		}
+	}
+	return true
+}
+
+// autocloseCandidates returns the open issues of the tree whose sub-issues are all closed,
+// deepest first. Closing an issue can complete its parent, so candidates count as closed
+// for the issues above them.
+func autocloseCandidates(tree *IssueNode, closed map[string]bool) []*IssueNode {
+	var candidates []*IssueNode
+	for _, child := range tree.Children {
+		candidates = append(candidates, autocloseCandidates(child, closed)...)
+	}
+	if tree.State == "open" && !closed[tree.ID] && allChildrenClosed(tree, closed) {
+		closed[tree.ID] = true
+		candidates = append(candidates, tree)
+	}
+	return candidates
+}
+
+// autocloseComment builds the comment left on an automatically closed issue
+func autocloseComment(node *IssueNode) string {
+	owner, repo, _ := strings.Cut(node.Repository, "/")
+
+	var comment strings.Builder
+	comment.WriteString(fmt.Sprintf("Closing automatically: all %d sub-issues are closed.\n\n", len(node.Children)))
+	for _, child := range node.Children {
+		comment.WriteString(fmt.Sprintf("- %s %s\n", child.Reference(owner, repo), child.Title))
+	}
+	return comment.String()
+}
+
+func runAutoclose(cmd *cobra.Command, args []string) error {
+	// Get default repository if not specified
+	var defaultOwner, defaultRepo string
+	if autocloseRepoFlag != "" {
+		parts := strings.Split(autocloseRepoFlag, "/")
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid repository format: %s (expected OWNER/REPO)", autocloseRepoFlag)
+		}
+		defaultOwner = parts[0]
+		defaultRepo = parts[1]
+	} else {
+		var err error
+		defaultOwner, defaultRepo, err = getDefaultRepo()
+		if err != nil {
+			return fmt.Errorf("no repository specified and could not determine from current directory: %w", err)
+		}
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	closed := make(map[string]bool)
+	var queue []*IssueNode
+
+	if len(args) == 1 {
+		parentRef, err := parseIssueReference(args[0], defaultOwner, defaultRepo)
+		if err != nil {
+			return fmt.Errorf("invalid parent issue: %w", err)
+		}
+		fmt.Fprintf(cmd.OutOrStderr(), "Getting sub-issue tree of #%d from %s/%s...\n",
+			parentRef.Number, parentRef.Owner, parentRef.Repo)
+		tree, err := getIssueTree(client, parentRef.Owner, parentRef.Repo, parentRef.Number, maxTreeDepth)
+		if err != nil {
+			return err
+		}
+		queue = autocloseCandidates(tree, closed)
+	} else {
+		fmt.Fprintf(cmd.OutOrStderr(), "Finding completed parent issues in %s/%s...\n", defaultOwner, defaultRepo)
+		parents, err := listCompletedParents(client, defaultOwner, defaultRepo)
+		if err != nil {
+			return err
+		}
+		for _, parent := range parents {
+			tree, err := getIssueTree(client, defaultOwner, defaultRepo, parent.Number, 1)
+			if err != nil {
+				return err
+			}
+			queue = append(queue, autocloseCandidates(tree, closed)...)
+		}
+	}
+
+	var errors []error
+
+	// Closing the candidates may complete their ancestors, which are listed for confirmation too
+	if autocloseUpFlag {
+		for i := 0; i < len(queue); i++ {
+			parent, err := getIssueParent(client, queue[i].ID)
+			if err != nil {
+				errors = append(errors, err)
+				continue
+			}
+			if parent == nil || closed[parent.ID] {
+				continue
+			}
+			parentRef, err := parseIssueURL(parent.URL)
+			if err != nil {
+				errors = append(errors, err)
+				continue
+			}
+			parentTree, err := getIssueTree(client, parentRef.Owner, parentRef.Repo, parentRef.Number, 1)
+			if err != nil {
+				errors = append(errors, err)
+				continue
+			}
+			queue = append(queue, autocloseCandidates(parentTree, closed)...)
+		}
+	}
+
+	// Get confirmation for repository-wide runs (nothing is changed in dry-run mode)
+	if len(args) == 0 && len(queue) > 0 && !autocloseForceFlag && !dryRunFlag {
+		for _, node := range queue {
+			fmt.Fprintf(cmd.OutOrStderr(), "  %s %s\n", node.Reference(defaultOwner, defaultRepo), node.Title)
+		}
+		fmt.Fprintf(cmd.OutOrStderr(), "Are you sure you want to close %d issue(s) in %s/%s? (y/N): ",
+			len(queue), defaultOwner, defaultRepo)
+		var response string
+		fmt.Scanln(&response)
+		if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
+			fmt.Fprintln(cmd.OutOrStderr(), "Autoclose cancelled")
+			return nil
+		}
+	}
+
+	var closedIssues []*IssueNode
+
+	for _, node := range queue {
+		// Candidates were chosen assuming the issues below them get closed, which may have failed
+		if !allChildrenClosed(node, closed) {
+			fmt.Fprintf(cmd.OutOrStderr(), "Skipping #%d: not all of its sub-issues could be closed\n", node.Number)
+			delete(closed, node.ID)
+			continue
+		}
+
+		fmt.Fprintf(cmd.OutOrStderr(), "#%d %s: all %d sub-issues are closed\n", node.Number, node.Title, len(node.Children))
+
+		if err := closeIssue(client, node.ID, "COMPLETED"); err != nil {
+			errors = append(errors, fmt.Errorf("#%d: %w", node.Number, err))
+			delete(closed, node.ID)
+			continue
+		}
+		if err := addComment(client, node.ID, autocloseComment(node)); err != nil {
+			warnf("#%d: %v", node.Number, err)
+		}
+		closedIssues = append(closedIssues, node)
+	}
+
+	if dryRunFlag {
+		for _, err := range errors {
+			warnf("%v", err)
+		}
+		return writePlan(cmd.OutOrStdout(), !term.IsTerminal(os.Stdout))
+	}
+
+	if len(queue) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "✓ No open parent issues with all sub-issues closed")
+		return nil
+	}
+
+	for _, node := range closedIssues {
+		fmt.Fprintf(cmd.OutOrStdout(), "✓ Closed %s: %s\n", node.Reference(defaultOwner, defaultRepo), node.Title)
+	}
+
+	// Display errors if any
+	if len(errors) > 0 {
+		fmt.Fprintln(cmd.OutOrStderr(), "\nErrors encountered:")
+		for _, err := range errors {
+			fmt.Fprintf(cmd.OutOrStderr(), "  - %v\n", err)
+		}
+		return fmt.Errorf("failed to close %d issue(s)", len(errors))
+	}
+
+	return nil
+}
+
+// listCompletedParents pages through the open issues of a repository and returns those
+// whose sub-issues are all closed
+func listCompletedParents(client *api.GraphQLClient, owner, repo string) ([]IssueInfo, error) {
+	query := `
+		query($owner: String!, $repo: String!, $cursor: String) {
+			repository(owner: $owner, name: $repo) {
+				issues(first: 100, after: $cursor, states: OPEN) {
+					nodes {
+						id
+						number
+						title
+						url
+						state
+						subIssuesSummary {
+							total
+							completed
+						}
+					}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+				}
+			}
+		}`
+
+	var parents []IssueInfo
+	var cursor *string
+
+	for {
+		variables := map[string]interface{}{
+			"owner":  owner,
+			"repo":   repo,
+			"cursor": cursor,
+		}
+
+		var response struct {
+			Repository struct {
+				Issues struct {
+					Nodes []struct {
+						IssueInfo
+						SubIssuesSummary struct {
+							Total     int `json:"total"`
+							Completed int `json:"completed"`
+						} `json:"subIssuesSummary"`
+					} `json:"nodes"`
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+				} `json:"issues"`
+			} `json:"repository"`
+		}
+
+		err := client.Do(query, variables, &response)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list issues: %w", err)
+		}
+
+		for _, node := range response.Repository.Issues.Nodes {
+			summary := node.SubIssuesSummary
+			if summary.Total > 0 && summary.Completed == summary.Total {
+				parents = append(parents, node.IssueInfo)
+			}
+		}
+
+		if !response.Repository.Issues.PageInfo.HasNextPage {
+			break
+		}
+		cursor = &response.Repository.Issues.PageInfo.EndCursor
+	}
+
+	return parents, nil
+}
+
+// getIssueParent gets the parent of an issue by node ID, or nil when it has none
+func getIssueParent(client *api.GraphQLClient, issueID string) (*IssueInfo, error) {
+	query := `
+		query($id: ID!) {
+			node(id: $id) {
+				... on Issue {
+					parent {
+						id
+						number
+						title
+						url
+						state
+					}
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"id": issueID,
+	}
+
+	var response struct {
+		Node struct {
+			Parent *IssueInfo `json:"parent"`
+		} `json:"node"`
+	}
+
+	err := client.Do(query, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parent issue: %w", err)
+	}
+
+	parent := response.Node.Parent
+	if parent != nil {
+		parent.State = strings.ToLower(parent.State)
+	}
+	return parent, nil
+}
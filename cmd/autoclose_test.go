@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAutocloseCandidates(t *testing.T) {
+	tree := testTree()
+	tree.ID = "I1"
+	tree.Children[0].ID = "I2"
+
+	// #2 still has an open sub-issue (#5), so nothing can be closed
+	assert.Empty(t, autocloseCandidates(tree, map[string]bool{}))
+
+	// Once #5 is closed, #2 completes and then #1
+	tree.Children[0].Children[1].State = "closed"
+	closed := map[string]bool{}
+	candidates := autocloseCandidates(tree, closed)
+	assert.Equal(t, []int{2, 1}, numbers(candidates))
+	assert.True(t, closed["I1"])
+	assert.True(t, closed["I2"])
+
+	// Issues already scheduled are not returned twice
+	assert.Empty(t, autocloseCandidates(tree, closed))
+
+	// If closing #2 fails, #1 is no longer complete
+	delete(closed, "I2")
+	assert.False(t, allChildrenClosed(tree, closed))
+}
+
+func TestAllChildrenClosed(t *testing.T) {
+	leaf := &IssueNode{Number: 1, State: "open"}
+	assert.False(t, allChildrenClosed(leaf, nil))
+
+	parent := &IssueNode{Number: 2, State: "open", Children: []*IssueNode{
+		{ID: "A", State: "closed"},
+		{ID: "B", State: "open"},
+	}}
+	assert.False(t, allChildrenClosed(parent, map[string]bool{}))
+	assert.True(t, allChildrenClosed(parent, map[string]bool{"B": true}))
+
+	// Not every sub-issue was fetched
+	parent.subIssueCount = 3
+	assert.False(t, allChildrenClosed(parent, map[string]bool{"B": true}))
+}
+
+func TestAutocloseComment(t *testing.T) {
+	node := &IssueNode{Number: 1, Repository: "owner/repo", Children: []*IssueNode{
+		{Number: 2, Title: "Backend", Repository: "owner/repo"},
+		{Number: 7, Title: "Library", Repository: "other/lib"},
+	}}
+
+	expected := "Closing automatically: all 2 sub-issues are closed.\n\n" +
+		"- #2 Backend\n" +
+		"- other/lib#7 Library\n"
+	assert.Equal(t, expected, autocloseComment(node))
+}
@@ -0,0 +1,362 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// batchItem is an issue to create from a --from-file task list
+type batchItem struct {
+	Title     string      `yaml:"title"`
+	Body      string      `yaml:"body"`
+	Labels    stringList  `yaml:"labels"`
+	Assignees stringList  `yaml:"assignees"`
+	Milestone string      `yaml:"milestone"`
+	Type      string      `yaml:"type"`
+	Projects  stringList  `yaml:"projects"`
+	Children  []batchItem `yaml:"children"`
+}
+
+// UnmarshalYAML accepts a plain string as a title-only item
+func (i *batchItem) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*i = batchItem{Title: value.Value}
+		return nil
+	}
+
+	type plain batchItem
+	var item plain
+	if err := value.Decode(&item); err != nil {
+		return err
+	}
+	*i = batchItem(item)
+	return nil
+}
+
+// batchResult is the outcome of one item of a batch
+type batchResult struct {
+	IssueResult
+	Depth int
+}
+
+// parseBatchText parses a plain text task list: one title per line, blank lines are ignored
+func parseBatchText(content string) []batchItem {
+	var items []batchItem
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		if title := strings.TrimSpace(line); title != "" {
+			items = append(items, batchItem{Title: title})
+		}
+	}
+	return items
+}
+
+// parseBatchYAML parses a YAML task list, a sequence of items with optional nested children
+func parseBatchYAML(content string) ([]batchItem, error) {
+	var items []batchItem
+	if err := yaml.Unmarshal([]byte(content), &items); err != nil {
+		return nil, fmt.Errorf("invalid task file: %w", err)
+	}
+	if err := validateBatchItems(items, ""); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// validateBatchItems checks that every item, including nested children, has a title
+func validateBatchItems(items []batchItem, path string) error {
+	for i := range items {
+		position := fmt.Sprintf("%s%d", path, i+1)
+		items[i].Title = strings.TrimSpace(items[i].Title)
+		if items[i].Title == "" {
+			return fmt.Errorf("invalid task file: item %s has no title", position)
+		}
+		if err := validateBatchItems(items[i].Children, position+"."); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadBatchFile reads a task list from a file, or from stdin when path is "-".
+// Files ending in .yml or .yaml are parsed as YAML, anything else as plain text.
+func loadBatchFile(path string, stdin io.Reader) ([]batchItem, error) {
+	var data []byte
+	var err error
+
+	if path == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read task file: %w", err)
+	}
+
+	var items []batchItem
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yml", ".yaml":
+		items, err = parseBatchYAML(string(data))
+		if err != nil {
+			return nil, err
+		}
+	default:
+		items = parseBatchText(string(data))
+	}
+
+	if len(items) == 0 {
+		return nil, fmt.Errorf("task file %s contains no issues", path)
+	}
+	return items, nil
+}
+
+// batchIssue merges an item with the metadata given on the command line
+func batchIssue(item batchItem, defaults *newSubIssue) *newSubIssue {
+	issue := &newSubIssue{
+		Title:             item.Title,
+		Body:              item.Body,
+		Labels:            mergeNames(item.Labels, defaults.Labels),
+		Assignees:         mergeNames(item.Assignees, defaults.Assignees),
+		Milestone:         item.Milestone,
+		Type:              item.Type,
+		Projects:          mergeNames(item.Projects, defaults.Projects),
+		InheritedProjects: defaults.InheritedProjects,
+		Fields:            defaults.Fields,
+	}
+	if issue.Milestone == "" {
+		issue.Milestone = defaults.Milestone
+	}
+	if issue.Type == "" {
+		issue.Type = defaults.Type
+	}
+	return issue
+}
+
+// batchCreator creates the items of a task list under their parents
+type batchCreator struct {
+	client   *api.GraphQLClient
+	owner    string
+	repo     string
+	repoID   string
+	defaults *newSubIssue
+	progress io.Writer
+	results  []batchResult
+}
+
+// create creates items under parentID, or as top-level issues when it is empty. Items whose
+// title already exists under the parent are reused, so re-running a partially failed batch
+// only creates what is missing. Items repeating a title of the same file are treated the same way.
+func (b *batchCreator) create(parentID string, items []batchItem, depth int) error {
+	existing := map[string]IssueInfo{}
+	if parentID != "" && !isPlannedID(parentID) {
+		children, err := getChildIssues(b.client, parentID)
+		if err != nil {
+			return fmt.Errorf("could not check existing sub-issues: %w", err)
+		}
+		for _, child := range children {
+			existing[child.Title] = child
+		}
+	}
+
+	for _, item := range items {
+		result := batchResult{
+			IssueResult: IssueResult{
+				Title:      item.Title,
+				Repository: fmt.Sprintf("%s/%s", b.owner, b.repo),
+			},
+			Depth: depth,
+		}
+
+		issue, ok := existing[item.Title]
+		if ok {
+			result.Number, result.URL, result.ID = issue.Number, issue.URL, issue.ID
+			result.Status = statusExists
+		} else {
+			fmt.Fprintf(b.progress, "%sCreating '%s'...\n", strings.Repeat("  ", depth), item.Title)
+			created, err := createSubIssueWithMetadata(io.Discard, b.client, b.owner, b.repo, b.repoID, parentID, batchIssue(item, b.defaults))
+			if err != nil {
+				result.Status = statusFailed
+				result.Error = err.Error()
+				b.results = append(b.results, result)
+				b.skip(item.Children, depth+1)
+				continue
+			}
+			result.Number, result.URL, result.ID = created.Number, created.URL, created.ID
+			result.Status = statusCreated
+			existing[item.Title] = *created
+		}
+
+		b.results = append(b.results, result)
+		if len(item.Children) > 0 {
+			if err := b.create(result.ID, item.Children, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// skip records the items below a failed item as skipped
+func (b *batchCreator) skip(items []batchItem, depth int) {
+	for _, item := range items {
+		b.results = append(b.results, batchResult{
+			IssueResult: IssueResult{
+				Title:      item.Title,
+				Repository: fmt.Sprintf("%s/%s", b.owner, b.repo),
+				Status:     statusSkipped,
+				Error:      "parent issue was not created",
+			},
+			Depth: depth,
+		})
+		b.skip(item.Children, depth+1)
+	}
+}
+
+// countBatchResults counts the results by status
+func countBatchResults(results []batchResult) map[string]int {
+	counts := map[string]int{}
+	for _, result := range results {
+		counts[result.Status]++
+	}
+	return counts
+}
+
+// formatBatchSummary renders the results of a batch as a table, indenting nested items
+func formatBatchSummary(results []batchResult) string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(w, "STATUS\tISSUE\tTITLE")
+	for _, result := range results {
+		number := "-"
+		if result.Number > 0 {
+			number = fmt.Sprintf("#%d", result.Number)
+		}
+		title := strings.Repeat("  ", result.Depth) + result.Title
+		if result.Status == statusFailed {
+			title += fmt.Sprintf(" (%s)", result.Error)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", result.Status, number, title)
+	}
+	w.Flush()
+
+	counts := countBatchResults(results)
+	fmt.Fprintf(&buf, "\n%d created, %d already existed, %d failed, %d skipped\n",
+		counts[statusCreated], counts[statusExists], counts[statusFailed], counts[statusSkipped])
+	return buf.String()
+}
+
+// getChildIssues gets all direct sub-issues of an issue by node ID, in order
+func getChildIssues(client *api.GraphQLClient, issueID string) ([]IssueInfo, error) {
+	query := `
+		query($id: ID!, $cursor: String) {
+			node(id: $id) {
+				... on Issue {
+					subIssues(first: 100, after: $cursor) {
+						nodes {
+							id
+							number
+							title
+							url
+							state
+						}
+						pageInfo {
+							hasNextPage
+							endCursor
+						}
+					}
+				}
+			}
+		}`
+
+	var children []IssueInfo
+	var cursor *string
+
+	for {
+		variables := map[string]interface{}{
+			"id":     issueID,
+			"cursor": cursor,
+		}
+
+		var response struct {
+			Node struct {
+				SubIssues struct {
+					Nodes    []IssueInfo `json:"nodes"`
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+				} `json:"subIssues"`
+			} `json:"node"`
+		}
+
+		err := client.Do(query, variables, &response)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get sub-issues: %w", err)
+		}
+		children = append(children, response.Node.SubIssues.Nodes...)
+
+		if !response.Node.SubIssues.PageInfo.HasNextPage {
+			break
+		}
+		cursor = &response.Node.SubIssues.PageInfo.EndCursor
+	}
+
+	for i := range children {
+		children[i].State = strings.ToLower(children[i].State)
+	}
+	return children, nil
+}
+
+// runBatchCreate creates the items of a task list in owner/repo under the parent and prints a summary
+func runBatchCreate(cmd *cobra.Command, client *api.GraphQLClient, parentRef *IssueReference, parent *IssueInfo, owner, repo, repoID string, items []batchItem, defaults *newSubIssue) error {
+	creator := &batchCreator{
+		client:   client,
+		owner:    owner,
+		repo:     repo,
+		repoID:   repoID,
+		defaults: defaults,
+		progress: cmd.OutOrStderr(),
+	}
+	createErr := creator.create(parent.ID, items, 0)
+	if createErr != nil && (dryRunFlag || len(creator.results) == 0) {
+		return createErr
+	}
+
+	if dryRunFlag {
+		return writePlan(cmd.OutOrStdout(), createJSONFlag || !term.IsTerminal(os.Stdout))
+	}
+
+	if createJSONFlag {
+		result := &MutationResult{Parent: newIssueResult(parentRef, parent)}
+		for _, r := range creator.results {
+			result.SubIssues = append(result.SubIssues, r.IssueResult)
+		}
+		if err := writeMutationResult(cmd.OutOrStdout(), result); err != nil {
+			return err
+		}
+	} else {
+		fmt.Fprint(cmd.OutOrStdout(), formatBatchSummary(creator.results))
+	}
+
+	if createErr != nil {
+		fmt.Fprintln(cmd.OutOrStderr(), "Re-run the same command to continue; sub-issues that already exist are not created again.")
+		return createErr
+	}
+
+	counts := countBatchResults(creator.results)
+	if failed := counts[statusFailed] + counts[statusSkipped]; failed > 0 {
+		fmt.Fprintln(cmd.OutOrStderr(), "Re-run the same command to retry; sub-issues that already exist are not created again.")
+		return fmt.Errorf("%d of %d sub-issues could not be created", failed, len(creator.results))
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBatchText(t *testing.T) {
+	items := parseBatchText("Design API\r\n\n  Write docs  \nShip it\n")
+
+	var titles []string
+	for _, item := range items {
+		titles = append(titles, item.Title)
+	}
+	assert.Equal(t, []string{"Design API", "Write docs", "Ship it"}, titles)
+}
+
+func TestParseBatchYAML(t *testing.T) {
+	content := `
+- Plain title
+- title: Backend
+  body: API work
+  labels: backend, api
+  assignees: [alice]
+  milestone: v1.0
+  type: Feature
+  projects: [Roadmap]
+  children:
+    - Schema
+    - title: Endpoints
+      labels: [api]
+`
+	items, err := parseBatchYAML(content)
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+
+	assert.Equal(t, "Plain title", items[0].Title)
+	assert.Empty(t, items[0].Children)
+
+	backend := items[1]
+	assert.Equal(t, "Backend", backend.Title)
+	assert.Equal(t, "API work", backend.Body)
+	assert.Equal(t, stringList{"backend", "api"}, backend.Labels)
+	assert.Equal(t, stringList{"alice"}, backend.Assignees)
+	assert.Equal(t, "v1.0", backend.Milestone)
+	assert.Equal(t, "Feature", backend.Type)
+	assert.Equal(t, stringList{"Roadmap"}, backend.Projects)
+	assert.Len(t, backend.Children, 2)
+	assert.Equal(t, "Schema", backend.Children[0].Title)
+	assert.Equal(t, stringList{"api"}, backend.Children[1].Labels)
+}
+
+func TestParseBatchYAMLErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "not a list",
+			content: "title: Task",
+			want:    "invalid task file",
+		},
+		{
+			name:    "missing title",
+			content: "- title: Parent\n  children:\n    - body: no title\n",
+			want:    "item 1.1 has no title",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseBatchYAML(tt.content)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadBatchFileFromStdin(t *testing.T) {
+	items, err := loadBatchFile("-", strings.NewReader("One\nTwo\n"))
+	assert.NoError(t, err)
+	assert.Len(t, items, 2)
+
+	_, err = loadBatchFile("-", strings.NewReader("\n\n"))
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "contains no issues")
+	}
+}
+
+func TestBatchIssue(t *testing.T) {
+	defaults := &newSubIssue{
+		Labels:    []string{"backend"},
+		Assignees: []string{"bob"},
+		Milestone: "v1.0",
+		Type:      "Task",
+		Projects:  []string{"Roadmap"},
+	}
+
+	issue := batchIssue(batchItem{Title: "Task", Labels: stringList{"api"}}, defaults)
+	assert.Equal(t, "Task", issue.Title)
+	assert.Equal(t, []string{"api", "backend"}, issue.Labels)
+	assert.Equal(t, []string{"bob"}, issue.Assignees)
+	assert.Equal(t, "v1.0", issue.Milestone)
+	assert.Equal(t, "Task", issue.Type)
+
+	// Item values take precedence over the command line
+	issue = batchIssue(batchItem{Title: "Bug", Milestone: "v2.0", Type: "Bug"}, defaults)
+	assert.Equal(t, "v2.0", issue.Milestone)
+	assert.Equal(t, "Bug", issue.Type)
+}
+
+func TestBatchCreatorDryRun(t *testing.T) {
+	withDryRun(t)
+
+	creator := &batchCreator{
+		owner:    "owner",
+		repo:     "repo",
+		repoID:   "R1",
+		defaults: &newSubIssue{},
+		progress: &strings.Builder{},
+	}
+	items := []batchItem{
+		{Title: "Backend", Children: []batchItem{{Title: "Schema"}}},
+		{Title: "Frontend"},
+	}
+	assert.NoError(t, creator.create("<parent>", items, 0))
+
+	assert.Len(t, creator.results, 3)
+	assert.Equal(t, "Backend", creator.results[0].Title)
+	assert.Equal(t, "Schema", creator.results[1].Title)
+	assert.Equal(t, 1, creator.results[1].Depth)
+	assert.Equal(t, statusCreated, creator.results[2].Status)
+
+	// Children are created under the planned parent issue
+	assert.Len(t, plan.Mutations, 3)
+	parentOf := func(i int) interface{} {
+		return plan.Mutations[i].Variables["input"].(map[string]interface{})["parentIssueId"]
+	}
+	assert.Equal(t, "<parent>", parentOf(0))
+	assert.Equal(t, "<createIssue#1>", parentOf(1))
+	assert.Equal(t, "<parent>", parentOf(2))
+}
+
+func TestBatchCreatorRepeatedTitle(t *testing.T) {
+	withDryRun(t)
+
+	creator := &batchCreator{
+		owner:    "owner",
+		repo:     "repo",
+		repoID:   "R1",
+		defaults: &newSubIssue{},
+		progress: &strings.Builder{},
+	}
+	items := []batchItem{
+		{Title: "Backend", Children: []batchItem{{Title: "Schema"}}},
+		{Title: "Backend", Children: []batchItem{{Title: "API"}}},
+	}
+	assert.NoError(t, creator.create("<parent>", items, 0))
+
+	// The second "Backend" reuses the issue created for the first, as a re-run would
+	assert.Len(t, creator.results, 4)
+	assert.Equal(t, statusExists, creator.results[2].Status)
+	assert.Equal(t, creator.results[0].ID, creator.results[2].ID)
+	assert.Len(t, plan.Mutations, 3)
+	assert.Equal(t, creator.results[0].ID, plan.Mutations[2].Variables["input"].(map[string]interface{})["parentIssueId"])
+}
+
+func TestFormatBatchSummary(t *testing.T) {
+	results := []batchResult{
+		{IssueResult: IssueResult{Number: 10, Title: "Backend", Status: statusCreated}},
+		{IssueResult: IssueResult{Number: 11, Title: "Schema", Status: statusExists}, Depth: 1},
+		{IssueResult: IssueResult{Title: "Frontend", Status: statusFailed, Error: "boom"}},
+		{IssueResult: IssueResult{Title: "Widgets", Status: statusSkipped}, Depth: 1},
+	}
+
+	output := formatBatchSummary(results)
+	lines := strings.Split(output, "\n")
+	assert.Contains(t, lines[0], "STATUS")
+	assert.Contains(t, lines[1], "#10")
+	assert.Contains(t, lines[2], "    Schema")
+	assert.Contains(t, lines[3], "Frontend (boom)")
+	assert.Contains(t, lines[4], "-")
+	assert.Contains(t, output, "1 created, 1 already existed, 1 failed, 1 skipped")
+}
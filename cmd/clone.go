@@ -0,0 +1,500 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/spf13/cobra"
+)
+
+// defaultLabelColor is used for created labels that do not exist in the source repository
+const defaultLabelColor = "ededed"
+
+var (
+	cloneToRepoFlag   string
+	cloneUnderFlag    string
+	cloneStateFlag    string
+	cloneLabelMapFlag []string
+	cloneRepoFlag     string
+	cloneJSONFlag     bool
+)
+
+var cloneCmd = &cobra.Command{
+	Use:   "clone <issue>",
+	Short: "Copy an issue and its sub-issues to another parent or repository",
+	Long: `Recreate an issue and all of its sub-issues, keeping titles, bodies, labels,
+assignees and the order of the hierarchy. The copies are new, open issues; the
+originals are not changed.
+
+Labels missing in the target repository are created with the color and
+description of the source label. Use --label-map to rename labels instead.
+Hierarchies that cannot be read completely are refused.
+
+Examples:
+  # Copy last sprint's epic within the same repository
+  gh sub-issue clone 123
+
+  # Copy a template epic into a team repository, under an existing issue
+  gh sub-issue clone https://github.com/org/templates/issues/5 --to-repo org/team --under 42
+
+  # Only the issues still open, with a label renamed
+  gh sub-issue clone 123 --to-repo org/team --state open --label-map type:bug=bug
+
+  # Print the old to new mapping as JSON
+  gh sub-issue clone 123 --to-repo org/team --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runClone,
+}
+
+func init() {
+	rootCmd.AddCommand(cloneCmd)
+	cloneCmd.Flags().StringVar(&cloneToRepoFlag, "to-repo", "", "Repository to create the copies in, in OWNER/REPO format (default: the source repository)")
+	cloneCmd.Flags().StringVar(&cloneUnderFlag, "under", "", "Create the copy as a sub-issue of this issue (number or URL in the target repository)")
+	cloneCmd.Flags().StringVarP(&cloneStateFlag, "state", "s", "all", "Copy only sub-issues in this state: {open|closed|all}")
+	cloneCmd.Flags().StringArrayVar(&cloneLabelMapFlag, "label-map", nil, "Rename a label in OLD=NEW format (repeatable)")
+	cloneCmd.Flags().StringVarP(&cloneRepoFlag, "repo", "R", "", "Repository of the source issue in OWNER/REPO format")
+	cloneCmd.Flags().BoolVar(&cloneJSONFlag, "json", false, "Output the mapping as JSON")
+}
+
+// CloneMapping relates a source issue to its copy
+type CloneMapping struct {
+	Title string      `json:"title"`
+	Depth int         `json:"depth"`
+	Old   IssueResult `json:"old"`
+	New   IssueResult `json:"new"`
+}
+
+// parseLabelMap parses OLD=NEW pairs into a map keyed by the lowercased old name
+func parseLabelMap(pairs []string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	for _, pair := range pairs {
+		from, to, ok := strings.Cut(pair, "=")
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if !ok || from == "" || to == "" {
+			return nil, fmt.Errorf("invalid label mapping: %s (expected OLD=NEW)", pair)
+		}
+		mapping[strings.ToLower(from)] = to
+	}
+	return mapping, nil
+}
+
+// mapLabels renames labels according to the mapping, dropping duplicates
+func mapLabels(labels []string, mapping map[string]string) []string {
+	var mapped []string
+	for _, label := range labels {
+		if to, ok := mapping[strings.ToLower(label)]; ok {
+			label = to
+		}
+		if !hasLabel(mapped, label) {
+			mapped = append(mapped, label)
+		}
+	}
+	return mapped
+}
+
+// cloneIncluded reports whether a sub-issue is copied with the --state filter
+func cloneIncluded(node *IssueNode, state string) bool {
+	return state == "all" || node.State == state
+}
+
+// cloneLabel is a label that the copies need
+type cloneLabel struct {
+	// Name is the label name in the target repository
+	Name string
+	// Source and Repository identify the label the name comes from
+	Source     string
+	Repository string
+}
+
+// cloneLabels returns the labels of the issues to copy, renamed by the mapping, in the
+// order they first appear
+func cloneLabels(tree *IssueNode, state string, mapping map[string]string) []cloneLabel {
+	var labels []cloneLabel
+	seen := make(map[string]bool)
+	var visit func(node *IssueNode)
+	visit = func(node *IssueNode) {
+		for _, label := range node.Labels {
+			name := mapLabels([]string{label}, mapping)[0]
+			if !seen[strings.ToLower(name)] {
+				seen[strings.ToLower(name)] = true
+				labels = append(labels, cloneLabel{Name: name, Source: label, Repository: node.Repository})
+			}
+		}
+		for _, child := range node.Children {
+			if cloneIncluded(child, state) {
+				visit(child)
+			}
+		}
+	}
+	visit(tree)
+	return labels
+}
+
+// formatCloneMapping formats the mapping as a table, indenting sub-issues
+func formatCloneMapping(mappings []CloneMapping) string {
+	var output strings.Builder
+
+	w := tabwriter.NewWriter(&output, 0, 0, 2, ' ', 0)
+	for _, m := range mappings {
+		to := "-"
+		if m.New.URL != "" {
+			to = m.New.URL
+		}
+		if m.New.Status == statusFailed || m.New.Status == statusSkipped {
+			to = fmt.Sprintf("%s (%s)", m.New.Status, m.New.Error)
+		}
+		fmt.Fprintf(w, "%s%s#%d\t→\t%s\n", strings.Repeat("  ", m.Depth), m.Old.Repository, m.Old.Number, to)
+	}
+	w.Flush()
+
+	return output.String()
+}
+
+// repoLabel is a label with its display settings
+type repoLabel struct {
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+}
+
+// listRepoLabels gets all labels of a repository with their color and description
+func listRepoLabels(client *api.GraphQLClient, owner, repo string) ([]repoLabel, error) {
+	query := `
+		query($owner: String!, $repo: String!, $cursor: String) {
+			repository(owner: $owner, name: $repo) {
+				labels(first: 100, after: $cursor) {
+					nodes {
+						name
+						color
+						description
+					}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+				}
+			}
+		}`
+
+	var labels []repoLabel
+	var cursor *string
+
+	for {
+		variables := map[string]interface{}{
+			"owner":  owner,
+			"repo":   repo,
+			"cursor": cursor,
+		}
+
+		var response struct {
+			Repository struct {
+				Labels struct {
+					Nodes    []repoLabel `json:"nodes"`
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+				} `json:"labels"`
+			} `json:"repository"`
+		}
+
+		err := client.Do(query, variables, &response)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get labels: %w", err)
+		}
+		labels = append(labels, response.Repository.Labels.Nodes...)
+
+		if !response.Repository.Labels.PageInfo.HasNextPage {
+			break
+		}
+		cursor = &response.Repository.Labels.PageInfo.EndCursor
+	}
+
+	return labels, nil
+}
+
+// createLabel creates a label in a repository
+func createLabel(client *api.GraphQLClient, repoID string, label repoLabel) error {
+	mutation := `
+		mutation CreateLabel($input: CreateLabelInput!) {
+			createLabel(input: $input) {
+				label {
+					id
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"repositoryId": repoID,
+			"name":         label.Name,
+			"color":        label.Color,
+			"description":  label.Description,
+		},
+	}
+
+	var response struct {
+		CreateLabel struct {
+			Label struct {
+				ID string `json:"id"`
+			} `json:"label"`
+		} `json:"createLabel"`
+	}
+
+	err := doMutation(client, "createLabel", fmt.Sprintf("Create label '%s'", label.Name), mutation, variables, &response)
+	if err != nil {
+		return fmt.Errorf("failed to create label '%s': %w", label.Name, err)
+	}
+
+	return nil
+}
+
+// ensureCloneLabels creates the labels that the copies need and the target repository lacks,
+// with the color and description of the source label
+func ensureCloneLabels(w io.Writer, client *api.GraphQLClient, owner, repo, repoID string, labels []cloneLabel) error {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	existing, err := listRepoLabels(client, owner, repo)
+	if err != nil {
+		return err
+	}
+	have := make(map[string]bool)
+	for _, label := range existing {
+		have[strings.ToLower(label.Name)] = true
+	}
+
+	// Source labels by repository, fetched when first needed
+	sources := make(map[string][]repoLabel)
+	for _, needed := range labels {
+		if have[strings.ToLower(needed.Name)] {
+			continue
+		}
+		if _, ok := sources[needed.Repository]; !ok {
+			sourceOwner, sourceRepo, _ := strings.Cut(needed.Repository, "/")
+			sources[needed.Repository], err = listRepoLabels(client, sourceOwner, sourceRepo)
+			if err != nil {
+				return err
+			}
+		}
+
+		label := repoLabel{Color: defaultLabelColor}
+		for _, l := range sources[needed.Repository] {
+			if strings.EqualFold(l.Name, needed.Source) {
+				label = l
+				break
+			}
+		}
+		label.Name = needed.Name
+
+		fmt.Fprintf(w, "Creating label '%s' in %s/%s...\n", label.Name, owner, repo)
+		if err := createLabel(client, repoID, label); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// issueCloner copies issues into the target repository
+type issueCloner struct {
+	client   *api.GraphQLClient
+	owner    string
+	repo     string
+	repoID   string
+	state    string
+	labelMap map[string]string
+	progress io.Writer
+	mappings []CloneMapping
+}
+
+// clone copies node under parentID, then its sub-issues in order
+func (c *issueCloner) clone(node *IssueNode, parentID string, depth int) {
+	mapping := CloneMapping{
+		Title: node.Title,
+		Depth: depth,
+		Old: IssueResult{
+			Number:     node.Number,
+			URL:        node.URL,
+			ID:         node.ID,
+			Repository: node.Repository,
+		},
+		New: IssueResult{Repository: fmt.Sprintf("%s/%s", c.owner, c.repo)},
+	}
+
+	fmt.Fprintf(c.progress, "%sCopying #%d '%s'...\n", strings.Repeat("  ", depth), node.Number, node.Title)
+	created, err := createSubIssueWithMetadata(io.Discard, c.client, c.owner, c.repo, c.repoID, parentID, &newSubIssue{
+		Title:     node.Title,
+		Body:      node.Body,
+		Labels:    mapLabels(node.Labels, c.labelMap),
+		Assignees: node.Assignees,
+	})
+	if err != nil {
+		mapping.New.Status = statusFailed
+		mapping.New.Error = err.Error()
+		c.mappings = append(c.mappings, mapping)
+		c.skip(node.Children, depth+1)
+		return
+	}
+
+	mapping.New.Number, mapping.New.URL, mapping.New.ID = created.Number, created.URL, created.ID
+	mapping.New.Title = created.Title
+	mapping.New.Status = statusCreated
+	c.mappings = append(c.mappings, mapping)
+
+	for _, child := range node.Children {
+		if cloneIncluded(child, c.state) {
+			c.clone(child, created.ID, depth+1)
+		}
+	}
+}
+
+// skip records the sub-issues of an issue that could not be copied
+func (c *issueCloner) skip(nodes []*IssueNode, depth int) {
+	for _, node := range nodes {
+		if !cloneIncluded(node, c.state) {
+			continue
+		}
+		c.mappings = append(c.mappings, CloneMapping{
+			Title: node.Title,
+			Depth: depth,
+			Old:   IssueResult{Number: node.Number, URL: node.URL, ID: node.ID, Repository: node.Repository},
+			New: IssueResult{
+				Repository: fmt.Sprintf("%s/%s", c.owner, c.repo),
+				Status:     statusSkipped,
+				Error:      "parent issue was not copied",
+			},
+		})
+		c.skip(node.Children, depth+1)
+	}
+}
+
+func runClone(cmd *cobra.Command, args []string) error {
+	switch cloneStateFlag {
+	case "open", "closed", "all":
+	default:
+		return fmt.Errorf("invalid state: %s (expected open, closed or all)", cloneStateFlag)
+	}
+
+	labelMap, err := parseLabelMap(cloneLabelMapFlag)
+	if err != nil {
+		return err
+	}
+
+	// Get default repository if not specified
+	var defaultOwner, defaultRepo string
+	if cloneRepoFlag != "" {
+		parts := strings.Split(cloneRepoFlag, "/")
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid repository format: %s (expected OWNER/REPO)", cloneRepoFlag)
+		}
+		defaultOwner = parts[0]
+		defaultRepo = parts[1]
+	} else {
+		defaultOwner, defaultRepo, err = getDefaultRepo()
+		if err != nil {
+			return fmt.Errorf("no repository specified and could not determine from current directory: %w", err)
+		}
+	}
+
+	ref, err := parseIssueReference(args[0], defaultOwner, defaultRepo)
+	if err != nil {
+		return fmt.Errorf("invalid issue: %w", err)
+	}
+
+	toOwner, toRepo := ref.Owner, ref.Repo
+	if cloneToRepoFlag != "" {
+		parts := strings.Split(cloneToRepoFlag, "/")
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid repository format: %s (expected OWNER/REPO)", cloneToRepoFlag)
+		}
+		toOwner, toRepo = parts[0], parts[1]
+	}
+
+	var underRef *IssueReference
+	if cloneUnderFlag != "" {
+		underRef, err = parseIssueReference(cloneUnderFlag, toOwner, toRepo)
+		if err != nil {
+			return fmt.Errorf("invalid parent issue: %w", err)
+		}
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStderr(), "Getting sub-issue tree of #%d from %s/%s...\n", ref.Number, ref.Owner, ref.Repo)
+	tree, err := getIssueTree(client, ref.Owner, ref.Repo, ref.Number, maxTreeDepth)
+	if err != nil {
+		return err
+	}
+	if missing := tree.missingFields(); len(missing) > 0 {
+		return fmt.Errorf("hierarchy of #%d is too large to clone: %s", ref.Number, strings.Join(missing, "; "))
+	}
+	if err := fetchBodies(client, tree); err != nil {
+		return err
+	}
+
+	parentID := ""
+	if underRef != nil {
+		fmt.Fprintf(cmd.OutOrStderr(), "Getting parent issue #%d from %s/%s...\n",
+			underRef.Number, underRef.Owner, underRef.Repo)
+		parent, err := getIssue(client, underRef.Owner, underRef.Repo, underRef.Number)
+		if err != nil {
+			return err
+		}
+		parentID = parent.ID
+	}
+
+	repoID, err := getRepositoryID(client, toOwner, toRepo)
+	if err != nil {
+		return err
+	}
+
+	if err := ensureCloneLabels(cmd.OutOrStderr(), client, toOwner, toRepo, repoID, cloneLabels(tree, cloneStateFlag, labelMap)); err != nil {
+		return err
+	}
+
+	cloner := &issueCloner{
+		client:   client,
+		owner:    toOwner,
+		repo:     toRepo,
+		repoID:   repoID,
+		state:    cloneStateFlag,
+		labelMap: labelMap,
+		progress: cmd.OutOrStderr(),
+	}
+	cloner.clone(tree, parentID, 0)
+
+	if dryRunFlag {
+		return writePlan(cmd.OutOrStdout(), cloneJSONFlag || !term.IsTerminal(os.Stdout))
+	}
+
+	if cloneJSONFlag {
+		jsonBytes, err := json.MarshalIndent(cloner.mappings, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(jsonBytes))
+	} else {
+		fmt.Fprint(cmd.OutOrStdout(), formatCloneMapping(cloner.mappings))
+	}
+
+	failed := 0
+	for _, m := range cloner.mappings {
+		if m.New.Status != statusCreated {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d issues could not be copied", failed, len(cloner.mappings))
+	}
+	return nil
+}
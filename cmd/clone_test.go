@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLabelMap(t *testing.T) {
+	mapping, err := parseLabelMap([]string{"Type:Bug=bug", " p1 = priority:high "})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"type:bug": "bug", "p1": "priority:high"}, mapping)
+
+	for _, pair := range []string{"bug", "=bug", "bug="} {
+		_, err := parseLabelMap([]string{pair})
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "expected OLD=NEW")
+		}
+	}
+}
+
+func TestMapLabels(t *testing.T) {
+	mapping := map[string]string{"type:bug": "bug", "p1": "priority"}
+	assert.Equal(t, []string{"bug", "priority", "ui"}, mapLabels([]string{"Type:Bug", "p1", "ui", "BUG"}, mapping))
+	assert.Nil(t, mapLabels(nil, mapping))
+}
+
+func TestCloneLabels(t *testing.T) {
+	tree := testTree()
+	tree.Labels = []string{"epic"}
+	tree.Children[0].Labels = []string{"Type:Bug", "Epic"}
+	tree.Children[0].Children[0].Labels = []string{"done"}
+	tree.Children[0].Children[1].Labels = []string{"lib"}
+	mapping := map[string]string{"type:bug": "bug"}
+
+	assert.Equal(t, []cloneLabel{
+		{Name: "epic", Source: "epic", Repository: "owner/repo"},
+		{Name: "bug", Source: "Type:Bug", Repository: "owner/repo"},
+		{Name: "done", Source: "done", Repository: "owner/repo"},
+		{Name: "lib", Source: "lib", Repository: "other/lib"},
+	}, cloneLabels(tree, "all", mapping))
+
+	// Closed sub-issues are not copied with --state open, nor are their labels
+	assert.Equal(t, []cloneLabel{
+		{Name: "epic", Source: "epic", Repository: "owner/repo"},
+		{Name: "bug", Source: "Type:Bug", Repository: "owner/repo"},
+		{Name: "lib", Source: "lib", Repository: "other/lib"},
+	}, cloneLabels(tree, "open", mapping))
+}
+
+func TestFormatCloneMapping(t *testing.T) {
+	mappings := []CloneMapping{
+		{Old: IssueResult{Number: 1, Repository: "org/templates"},
+			New: IssueResult{Number: 10, URL: "https://github.com/org/team/issues/10", Status: statusCreated}},
+		{Depth: 1, Old: IssueResult{Number: 2, Repository: "org/templates"},
+			New: IssueResult{Status: statusFailed, Error: "boom"}},
+		{Depth: 2, Old: IssueResult{Number: 3, Repository: "org/templates"},
+			New: IssueResult{Status: statusSkipped, Error: "parent issue was not copied"}},
+	}
+
+	expected := "org/templates#1      →  https://github.com/org/team/issues/10\n" +
+		"  org/templates#2    →  failed (boom)\n" +
+		"    org/templates#3  →  skipped (parent issue was not copied)\n"
+	assert.Equal(t, expected, formatCloneMapping(mappings))
+}
@@ -0,0 +1,467 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/spf13/cobra"
+)
+
+var (
+	stateRepoFlag      string
+	stateRecursiveFlag bool
+	stateCommentFlag   string
+	stateForceFlag     bool
+	stateJSONFlag      bool
+	closeReasonFlag    string
+	reopenReasonFlag   string
+)
+
+var closeCmd = &cobra.Command{
+	Use:   "close <issue>",
+	Short: "Close an issue, optionally with all of its sub-issues",
+	Long: `Close an issue. With --recursive, every open issue of its sub-issue tree is
+closed as well, deepest issues first.
+
+Examples:
+  # Close epic #123 and everything below it as not planned
+  gh sub-issue close 123 --recursive --reason not_planned
+
+  # Leave a comment on every closed issue
+  gh sub-issue close 123 --recursive --comment "Cancelled, see #99"
+
+  # Skip the confirmation prompt
+  gh sub-issue close 123 --recursive --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runStateChange(cmd, args, closeAction)
+	},
+}
+
+var reopenCmd = &cobra.Command{
+	Use:   "reopen <issue>",
+	Short: "Reopen an issue, optionally with all of its sub-issues",
+	Long: `Reopen an issue. With --recursive, the closed issues of its sub-issue tree
+are reopened as well, parents first. Only sub-issues closed as not planned are
+reopened by default, so a cancelled epic comes back without its finished work;
+use --reason to pick another reason.
+
+Examples:
+  # Reopen epic #123 and the sub-issues cancelled with it
+  gh sub-issue reopen 123 --recursive
+
+  # Reopen every closed sub-issue, whatever the reason
+  gh sub-issue reopen 123 --recursive --reason any
+
+  # Reopen with a comment on every issue
+  gh sub-issue reopen 123 --recursive --comment "Back on the roadmap"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runStateChange(cmd, args, reopenAction)
+	},
+}
+
+func init() {
+	for _, c := range []*cobra.Command{closeCmd, reopenCmd} {
+		rootCmd.AddCommand(c)
+		c.Flags().StringVarP(&stateRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+		c.Flags().BoolVarP(&stateRecursiveFlag, "recursive", "r", false, "Include all sub-issues of the issue")
+		c.Flags().StringVarP(&stateCommentFlag, "comment", "c", "", "Leave a comment on every changed issue")
+		c.Flags().BoolVarP(&stateForceFlag, "force", "f", false, "Skip confirmation prompt")
+		c.Flags().BoolVar(&stateJSONFlag, "json", false, "Output per-issue results as JSON")
+	}
+	closeCmd.Flags().StringVar(&closeReasonFlag, "reason", "completed", "Reason for closing: {completed|not_planned}")
+	reopenCmd.Flags().StringVar(&reopenReasonFlag, "reason", "not_planned", "Only reopen sub-issues closed for this reason: {completed|not_planned|any}")
+}
+
+// stateAction describes a close or reopen run
+type stateAction struct {
+	Verb        string // "close"
+	Past        string // "closed"
+	Progressive string // "Closing"
+	Target      string // state of the issues afterwards
+	Status      string // result status of changed issues
+	Closing     bool
+}
+
+var (
+	closeAction  = stateAction{Verb: "close", Past: "closed", Progressive: "Closing", Target: "closed", Status: statusClosed, Closing: true}
+	reopenAction = stateAction{Verb: "reopen", Past: "reopened", Progressive: "Reopening", Target: "open", Status: statusReopened}
+)
+
+// parseCloseReason converts a --reason value into an IssueClosedStateReason
+func parseCloseReason(reason string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(reason)) {
+	case "completed", "":
+		return "COMPLETED", nil
+	case "not_planned", "not planned":
+		return "NOT_PLANNED", nil
+	}
+	return "", fmt.Errorf("invalid reason: %s (expected completed or not_planned)", reason)
+}
+
+// parseReopenReason converts a reopen --reason value into the state reason sub-issues must
+// have been closed with to be reopened, "" for any reason
+func parseReopenReason(reason string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(reason)) {
+	case "any", "all":
+		return "", nil
+	case "completed":
+		return "completed", nil
+	case "not_planned", "not planned", "":
+		return "not_planned", nil
+	}
+	return "", fmt.Errorf("invalid reason: %s (expected completed, not_planned or any)", reason)
+}
+
+// stateChangeTargets returns the issues of the tree that are not in the target state, in the
+// order to change them: children before parents when closing, parents first when reopening.
+// When reopening with a reason, sub-issues closed for another reason are skipped; the root is
+// always included. It also returns the number of issues already in the target state and the
+// number of skipped issues.
+func stateChangeTargets(tree *IssueNode, action stateAction, reason string) ([]*IssueNode, int, int) {
+	var ordered []*IssueNode
+	tree.Walk(func(node *IssueNode, depth int) {
+		ordered = append(ordered, node)
+	})
+	if action.Closing {
+		for i, j := 0, len(ordered)-1; i < j; i, j = i+1, j-1 {
+			ordered[i], ordered[j] = ordered[j], ordered[i]
+		}
+	}
+
+	var targets []*IssueNode
+	unchanged, skipped := 0, 0
+	for _, node := range ordered {
+		if node.State == action.Target {
+			unchanged++
+			continue
+		}
+		if !action.Closing && reason != "" && node != tree && node.StateReason != reason {
+			skipped++
+			continue
+		}
+		targets = append(targets, node)
+	}
+	return targets, unchanged, skipped
+}
+
+// stateChangePrompt builds the confirmation prompt of a recursive close or reopen
+func stateChangePrompt(action stateAction, root *IssueNode, targets []*IssueNode, unchanged, skipped int, reason string) string {
+	subIssues := len(targets)
+	for _, node := range targets {
+		if node == root {
+			subIssues--
+		}
+	}
+
+	var what string
+	if subIssues == len(targets) {
+		what = fmt.Sprintf("%d sub-issues of #%d", subIssues, root.Number)
+	} else {
+		what = fmt.Sprintf("#%d and %d sub-issues", root.Number, subIssues)
+	}
+
+	var notes []string
+	if unchanged > 0 {
+		notes = append(notes, fmt.Sprintf("%d already %s", unchanged, action.Target))
+	}
+	if skipped > 0 {
+		notes = append(notes, fmt.Sprintf("%d not closed as %s skipped", skipped, reasonText(reason)))
+	}
+
+	prompt := fmt.Sprintf("Are you sure you want to %s %s", action.Verb, what)
+	if len(notes) > 0 {
+		prompt += " (" + strings.Join(notes, ", ") + ")"
+	}
+	return prompt + "? (y/N): "
+}
+
+// reasonText returns a state reason as it reads in a sentence
+func reasonText(reason string) string {
+	return strings.ReplaceAll(reason, "_", " ")
+}
+
+func runStateChange(cmd *cobra.Command, args []string, action stateAction) error {
+	reason, err := parseCloseReason(closeReasonFlag)
+	if err != nil {
+		return err
+	}
+	reopenReason, err := parseReopenReason(reopenReasonFlag)
+	if err != nil {
+		return err
+	}
+
+	// Get default repository if not specified
+	var defaultOwner, defaultRepo string
+	if stateRepoFlag != "" {
+		parts := strings.Split(stateRepoFlag, "/")
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid repository format: %s (expected OWNER/REPO)", stateRepoFlag)
+		}
+		defaultOwner = parts[0]
+		defaultRepo = parts[1]
+	} else {
+		defaultOwner, defaultRepo, err = getDefaultRepo()
+		if err != nil {
+			return fmt.Errorf("no repository specified and could not determine from current directory: %w", err)
+		}
+	}
+
+	issueRef, err := parseIssueReference(args[0], defaultOwner, defaultRepo)
+	if err != nil {
+		return fmt.Errorf("invalid issue: %w", err)
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	depth := 0
+	if stateRecursiveFlag {
+		depth = maxTreeDepth
+		fmt.Fprintf(cmd.OutOrStderr(), "Getting sub-issue tree of #%d from %s/%s...\n",
+			issueRef.Number, issueRef.Owner, issueRef.Repo)
+	}
+	tree, err := getIssueTree(client, issueRef.Owner, issueRef.Repo, issueRef.Number, depth)
+	if err != nil {
+		return err
+	}
+
+	targets, unchanged, skipped := stateChangeTargets(tree, action, reopenReason)
+	if len(targets) == 0 {
+		if skipped > 0 {
+			fmt.Fprintf(cmd.OutOrStdout(), "✓ Nothing to %s: %d issue(s) are already %s, %d not closed as %s were skipped\n",
+				action.Verb, unchanged, action.Target, skipped, reasonText(reopenReason))
+			return nil
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "✓ Nothing to %s: all %d issue(s) are already %s\n",
+			action.Verb, unchanged, action.Target)
+		return nil
+	}
+
+	// Get confirmation for recursive changes (nothing is changed in dry-run mode)
+	if stateRecursiveFlag && !stateForceFlag && !dryRunFlag {
+		fmt.Fprint(cmd.OutOrStderr(), stateChangePrompt(action, tree, targets, unchanged, skipped, reopenReason))
+		var response string
+		fmt.Scanln(&response)
+		if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
+			fmt.Fprintln(cmd.OutOrStderr(), "Operation cancelled")
+			return nil
+		}
+	}
+
+	result := &MutationResult{Parent: treeIssueResult(tree, statusUnchanged)}
+	var changed []*IssueNode
+	var errors []error
+
+	for _, node := range targets {
+		fmt.Fprintf(cmd.OutOrStderr(), "%s #%d...\n", action.Progressive, node.Number)
+		if action.Closing {
+			err = closeIssue(client, node.ID, reason)
+		} else {
+			err = reopenIssue(client, node.ID)
+		}
+
+		var nodeResult IssueResult
+		if err != nil {
+			err = fmt.Errorf("#%d: %w", node.Number, err)
+			errors = append(errors, err)
+			nodeResult = treeIssueResult(node, statusFailed)
+			nodeResult.Error = err.Error()
+		} else {
+			changed = append(changed, node)
+			nodeResult = treeIssueResult(node, action.Status)
+			if stateCommentFlag != "" {
+				if err := addComment(client, node.ID, stateCommentFlag); err != nil {
+					warnf("#%d: %v", node.Number, err)
+				}
+			}
+		}
+
+		if node == tree {
+			result.Parent = nodeResult
+		} else {
+			result.SubIssues = append(result.SubIssues, nodeResult)
+		}
+	}
+
+	// Issues already in the target state or skipped are reported as unchanged
+	tree.Walk(func(node *IssueNode, depth int) {
+		if node != tree && !containsNode(targets, node) {
+			result.SubIssues = append(result.SubIssues, treeIssueResult(node, statusUnchanged))
+		}
+	})
+
+	if dryRunFlag {
+		for _, err := range errors {
+			warnf("%v", err)
+		}
+		return writePlan(cmd.OutOrStdout(), stateJSONFlag || !term.IsTerminal(os.Stdout))
+	}
+
+	if stateJSONFlag {
+		if err := writeMutationResult(cmd.OutOrStdout(), result); err != nil {
+			return err
+		}
+		if len(changed) == 0 {
+			return fmt.Errorf("failed to %s any issues", action.Verb)
+		}
+		return nil
+	}
+
+	// Display results
+	if len(changed) == 1 {
+		fmt.Fprintf(cmd.OutOrStdout(), "✓ %s #%d: %s\n", strings.ToUpper(action.Past[:1])+action.Past[1:], changed[0].Number, changed[0].Title)
+	} else if len(changed) > 1 {
+		fmt.Fprintf(cmd.OutOrStdout(), "✓ %s %d issues:\n", strings.ToUpper(action.Past[:1])+action.Past[1:], len(changed))
+		for _, node := range changed {
+			fmt.Fprintf(cmd.OutOrStdout(), "  - %s %s\n", node.Reference(issueRef.Owner, issueRef.Repo), node.Title)
+		}
+	}
+	if unchanged > 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "%d issue(s) were already %s\n", unchanged, action.Target)
+	}
+	if skipped > 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "%d issue(s) not closed as %s were left closed (use --reason any to reopen them)\n",
+			skipped, reasonText(reopenReason))
+	}
+
+	// Display errors if any
+	if len(errors) > 0 {
+		fmt.Fprintln(cmd.OutOrStderr(), "\nErrors encountered:")
+		for _, err := range errors {
+			fmt.Fprintf(cmd.OutOrStderr(), "  - %v\n", err)
+		}
+		if len(changed) == 0 {
+			return fmt.Errorf("failed to %s any issues", action.Verb)
+		}
+	}
+
+	return nil
+}
+
+// treeIssueResult builds an IssueResult for an issue of a tree
+func treeIssueResult(node *IssueNode, status string) IssueResult {
+	return IssueResult{
+		Number:     node.Number,
+		Title:      node.Title,
+		URL:        node.URL,
+		ID:         node.ID,
+		Repository: node.Repository,
+		Status:     status,
+	}
+}
+
+// containsNode reports whether nodes contains node
+func containsNode(nodes []*IssueNode, node *IssueNode) bool {
+	for _, n := range nodes {
+		if n == node {
+			return true
+		}
+	}
+	return false
+}
+
+// closeIssue closes an issue with a state reason (COMPLETED or NOT_PLANNED)
+func closeIssue(client *api.GraphQLClient, issueID, reason string) error {
+	mutation := `
+		mutation CloseIssue($id: ID!, $reason: IssueClosedStateReason) {
+			closeIssue(input: {issueId: $id, stateReason: $reason}) {
+				issue {
+					number
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"id":     issueID,
+		"reason": reason,
+	}
+
+	var response struct {
+		CloseIssue struct {
+			Issue struct {
+				Number int `json:"number"`
+			} `json:"issue"`
+		} `json:"closeIssue"`
+	}
+
+	err := doMutation(client, "closeIssue", "Close issue", mutation, variables, &response)
+	if err != nil {
+		return fmt.Errorf("failed to close issue: %w", err)
+	}
+
+	return nil
+}
+
+// reopenIssue reopens a closed issue
+func reopenIssue(client *api.GraphQLClient, issueID string) error {
+	mutation := `
+		mutation ReopenIssue($id: ID!) {
+			reopenIssue(input: {issueId: $id}) {
+				issue {
+					number
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"id": issueID,
+	}
+
+	var response struct {
+		ReopenIssue struct {
+			Issue struct {
+				Number int `json:"number"`
+			} `json:"issue"`
+		} `json:"reopenIssue"`
+	}
+
+	err := doMutation(client, "reopenIssue", "Reopen issue", mutation, variables, &response)
+	if err != nil {
+		return fmt.Errorf("failed to reopen issue: %w", err)
+	}
+
+	return nil
+}
+
+// addComment adds a comment to an issue
+func addComment(client *api.GraphQLClient, issueID, body string) error {
+	mutation := `
+		mutation AddComment($id: ID!, $body: String!) {
+			addComment(input: {subjectId: $id, body: $body}) {
+				commentEdge {
+					node {
+						id
+					}
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"id":   issueID,
+		"body": body,
+	}
+
+	var response struct {
+		AddComment struct {
+			CommentEdge struct {
+				Node struct {
+					ID string `json:"id"`
+				} `json:"node"`
+			} `json:"commentEdge"`
+		} `json:"addComment"`
+	}
+
+	err := doMutation(client, "addComment", "Comment on issue", mutation, variables, &response)
+	if err != nil {
+		return fmt.Errorf("failed to add comment: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCloseReason(t *testing.T) {
+	tests := []struct {
+		reason  string
+		want    string
+		wantErr bool
+	}{
+		{"completed", "COMPLETED", false},
+		{"", "COMPLETED", false},
+		{"not_planned", "NOT_PLANNED", false},
+		{"Not Planned", "NOT_PLANNED", false},
+		{"duplicate", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reason, func(t *testing.T) {
+			got, err := parseCloseReason(tt.reason)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func numbers(nodes []*IssueNode) []int {
+	var result []int
+	for _, node := range nodes {
+		result = append(result, node.Number)
+	}
+	return result
+}
+
+func TestParseReopenReason(t *testing.T) {
+	tests := []struct {
+		reason  string
+		want    string
+		wantErr bool
+	}{
+		{"not_planned", "not_planned", false},
+		{"", "not_planned", false},
+		{"completed", "completed", false},
+		{"any", "", false},
+		{"duplicate", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.reason, func(t *testing.T) {
+			got, err := parseReopenReason(tt.reason)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// reasonTree is a closed epic with a cancelled and a finished sub-issue
+func reasonTree() *IssueNode {
+	return &IssueNode{Number: 1, State: "closed", StateReason: "completed", Children: []*IssueNode{
+		{Number: 2, State: "closed", StateReason: "not_planned", Children: []*IssueNode{
+			{Number: 4, State: "closed", StateReason: "completed"},
+		}},
+		{Number: 3, State: "open"},
+	}}
+}
+
+func TestStateChangeTargets(t *testing.T) {
+	// Closing goes deepest first and skips closed issues
+	targets, unchanged, skipped := stateChangeTargets(testTree(), closeAction, "not_planned")
+	assert.Equal(t, []int{5, 2, 1}, numbers(targets))
+	assert.Equal(t, 2, unchanged)
+	assert.Equal(t, 0, skipped)
+
+	// Reopening goes parents first and skips open issues
+	targets, unchanged, skipped = stateChangeTargets(testTree(), reopenAction, "")
+	assert.Equal(t, []int{4, 3}, numbers(targets))
+	assert.Equal(t, 3, unchanged)
+	assert.Equal(t, 0, skipped)
+}
+
+func TestStateChangeTargetsReopenReason(t *testing.T) {
+	// Only cancelled sub-issues come back by default; the root is always reopened
+	targets, unchanged, skipped := stateChangeTargets(reasonTree(), reopenAction, "not_planned")
+	assert.Equal(t, []int{1, 2}, numbers(targets))
+	assert.Equal(t, 1, unchanged)
+	assert.Equal(t, 1, skipped)
+
+	targets, _, skipped = stateChangeTargets(reasonTree(), reopenAction, "completed")
+	assert.Equal(t, []int{1, 4}, numbers(targets))
+	assert.Equal(t, 1, skipped)
+
+	targets, _, skipped = stateChangeTargets(reasonTree(), reopenAction, "")
+	assert.Equal(t, []int{1, 2, 4}, numbers(targets))
+	assert.Equal(t, 0, skipped)
+}
+
+func TestStateChangePrompt(t *testing.T) {
+	tree := testTree()
+
+	targets, unchanged, skipped := stateChangeTargets(tree, closeAction, "not_planned")
+	assert.Equal(t, "Are you sure you want to close #1 and 2 sub-issues (2 already closed)? (y/N): ",
+		stateChangePrompt(closeAction, tree, targets, unchanged, skipped, "not_planned"))
+
+	targets, unchanged, skipped = stateChangeTargets(tree, reopenAction, "")
+	assert.Equal(t, "Are you sure you want to reopen 2 sub-issues of #1 (3 already open)? (y/N): ",
+		stateChangePrompt(reopenAction, tree, targets, unchanged, skipped, ""))
+
+	tree = reasonTree()
+	targets, unchanged, skipped = stateChangeTargets(tree, reopenAction, "not_planned")
+	assert.Equal(t, "Are you sure you want to reopen #1 and 1 sub-issues (1 already open, 1 not closed as not planned skipped)? (y/N): ",
+		stateChangePrompt(reopenAction, tree, targets, unchanged, skipped, "not_planned"))
+}
+
+func TestCloseAndReopenFlags(t *testing.T) {
+	for _, name := range []string{"close", "reopen"} {
+		t.Run(name, func(t *testing.T) {
+			cmd, _, err := rootCmd.Find([]string{name})
+			assert.NoError(t, err)
+			for _, flag := range []string{"recursive", "comment", "force", "repo", "json"} {
+				assert.NotNil(t, cmd.Flags().Lookup(flag), flag)
+			}
+		})
+	}
+
+	for _, name := range []string{"close", "reopen"} {
+		cmd, _, _ := rootCmd.Find([]string{name})
+		assert.NotNil(t, cmd.Flags().Lookup("reason"), name)
+	}
+}
@@ -0,0 +1,396 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/spf13/cobra"
+)
+
+var (
+	convertRepoFlag string
+	convertJSONFlag bool
+)
+
+var convertTasklistCmd = &cobra.Command{
+	Use:   "convert-tasklist <parent-issue>",
+	Short: "Convert the task list of an issue into sub-issues",
+	Long: `Convert the "- [ ]" task list items in the body of a parent issue into sub-issues.
+
+Each item becomes a new sub-issue titled after the item text. Checked items are
+created and then closed as completed. Items that are only a reference to an
+existing issue ("- [ ] #456", "- [ ] owner/repo#456" or an issue URL), or a
+reference in the rewritten form below, link that issue instead, closing it if the
+item is checked. Any other item is plain text, even if it starts with a reference.
+The task list is then rewritten to reference the sub-issues
+("- [ ] #124 — Write docs"), so running the command again only picks up new items.
+
+Examples:
+  # Convert the task list of issue #123
+  gh sub-issue convert-tasklist 123
+
+  # Preview the new issues and the rewritten body
+  gh sub-issue convert-tasklist 123 --dry-run
+
+  # Using a URL
+  gh sub-issue convert-tasklist https://github.com/owner/repo/issues/123`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConvertTasklist,
+}
+
+func init() {
+	rootCmd.AddCommand(convertTasklistCmd)
+	convertTasklistCmd.Flags().StringVarP(&convertRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+	convertTasklistCmd.Flags().BoolVar(&convertJSONFlag, "json", false, "Output per-issue results as JSON")
+}
+
+// taskItemPattern matches a task list item: indentation and bullet, checkbox state, text
+var taskItemPattern = regexp.MustCompile(`^(\s*[-*+]\s+\[)([ xX])(\]\s+)(.*\S)\s*$`)
+
+// taskReferenceSeparator separates the reference of a converted item from its original text
+const taskReferenceSeparator = " — "
+
+// taskReferencePattern matches an item that is only an issue reference such as #456 or
+// owner/repo#456, or a reference followed by the text kept by rewriteTasklist. Items
+// that merely start with a reference ("#1 priority: fix login") are plain text.
+var taskReferencePattern = regexp.MustCompile(`^(?:([\w.-]+)/([\w.-]+))?#(\d+)(?:` + taskReferenceSeparator + `.*)?$`)
+
+// TaskItem is a task list item found in an issue body
+type TaskItem struct {
+	Line    int
+	Checked bool
+	Text    string
+	Ref     *IssueReference
+}
+
+// parseTasklist finds the task list items of a body, ignoring fenced code blocks.
+// Items that reference an issue get a Ref resolved against owner/repo.
+func parseTasklist(body, owner, repo string) []TaskItem {
+	var items []TaskItem
+	inFence := false
+
+	for i, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		match := taskItemPattern.FindStringSubmatch(strings.TrimRight(line, "\r"))
+		if match == nil {
+			continue
+		}
+		items = append(items, TaskItem{
+			Line:    i,
+			Checked: match[2] != " ",
+			Text:    match[4],
+			Ref:     parseTaskReference(match[4], owner, repo),
+		})
+	}
+
+	return items
+}
+
+// parseTaskReference returns the issue referenced by a task item, or nil for plain text
+func parseTaskReference(text, owner, repo string) *IssueReference {
+	if strings.HasPrefix(text, "http://") || strings.HasPrefix(text, "https://") {
+		url, rest, found := strings.Cut(text, taskReferenceSeparator)
+		if strings.ContainsAny(url, " \t") || (found && rest == "") {
+			return nil
+		}
+		ref, err := parseIssueURL(url)
+		if err != nil {
+			return nil
+		}
+		return ref
+	}
+
+	match := taskReferencePattern.FindStringSubmatch(text)
+	if match == nil {
+		return nil
+	}
+	number, err := strconv.Atoi(match[3])
+	if err != nil || number <= 0 {
+		return nil
+	}
+	if match[1] != "" {
+		owner, repo = match[1], match[2]
+	}
+	return &IssueReference{Owner: owner, Repo: repo, Number: number}
+}
+
+// taskReferenceText returns the text of a converted item: the reference, then the original text
+func taskReferenceText(reference, text string) string {
+	return reference + taskReferenceSeparator + text
+}
+
+// rewriteTasklist replaces the text of the task items on the given lines, keeping bullets and checkboxes
+func rewriteTasklist(body string, replacements map[int]string) string {
+	lines := strings.Split(body, "\n")
+	for i, text := range replacements {
+		if i >= len(lines) {
+			continue
+		}
+		match := taskItemPattern.FindStringSubmatch(strings.TrimRight(lines[i], "\r"))
+		if match == nil {
+			continue
+		}
+		suffix := ""
+		if strings.HasSuffix(lines[i], "\r") {
+			suffix = "\r"
+		}
+		lines[i] = match[1] + match[2] + match[3] + text + suffix
+	}
+	return strings.Join(lines, "\n")
+}
+
+func runConvertTasklist(cmd *cobra.Command, args []string) error {
+	// Get default repository if not specified
+	var defaultOwner, defaultRepo string
+	if convertRepoFlag != "" {
+		parts := strings.Split(convertRepoFlag, "/")
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid repository format: %s (expected OWNER/REPO)", convertRepoFlag)
+		}
+		defaultOwner = parts[0]
+		defaultRepo = parts[1]
+	} else {
+		var err error
+		defaultOwner, defaultRepo, err = getDefaultRepo()
+		if err != nil {
+			return fmt.Errorf("no repository specified and could not determine from current directory: %w", err)
+		}
+	}
+
+	parentRef, err := parseIssueReference(args[0], defaultOwner, defaultRepo)
+	if err != nil {
+		return fmt.Errorf("invalid parent issue: %w", err)
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStderr(), "Getting parent issue #%d from %s/%s...\n",
+		parentRef.Number, parentRef.Owner, parentRef.Repo)
+	parent, err := getIssue(client, parentRef.Owner, parentRef.Repo, parentRef.Number)
+	if err != nil {
+		return err
+	}
+	body, err := getIssueBody(client, parent.ID)
+	if err != nil {
+		return err
+	}
+
+	items := parseTasklist(body, parentRef.Owner, parentRef.Repo)
+	if len(items) == 0 {
+		return fmt.Errorf("no task list items found in #%d", parentRef.Number)
+	}
+
+	// Issues that are already sub-issues are left alone
+	children, err := getChildIssues(client, parent.ID)
+	if err != nil {
+		return err
+	}
+	childIDs := make([]string, 0, len(children))
+	for _, child := range children {
+		childIDs = append(childIDs, child.ID)
+	}
+
+	repoID, err := getRepositoryID(client, parentRef.Owner, parentRef.Repo)
+	if err != nil {
+		return err
+	}
+
+	result := &MutationResult{Parent: newIssueResult(parentRef, parent)}
+	replacements := make(map[int]string)
+	failures := 0
+
+	for _, item := range items {
+		if item.Ref != nil {
+			sub, err := getIssue(client, item.Ref.Owner, item.Ref.Repo, item.Ref.Number)
+			if err != nil {
+				failures++
+				result.SubIssues = append(result.SubIssues, failedIssueResult(item.Ref, nil, err))
+				continue
+			}
+
+			// A checked item means the referenced issue is done
+			if item.Checked && strings.EqualFold(sub.State, "open") {
+				fmt.Fprintf(cmd.OutOrStderr(), "Closing #%d...\n", sub.Number)
+				if err := closeIssue(client, sub.ID, "COMPLETED"); err != nil {
+					warnf("#%d is checked but could not be closed: %v", sub.Number, err)
+				}
+			}
+
+			subResult := newIssueResult(item.Ref, sub)
+			subResult.Title = sub.Title
+			if containsID(childIDs, sub.ID) {
+				subResult.Status = statusExists
+				result.SubIssues = append(result.SubIssues, subResult)
+				continue
+			}
+
+			fmt.Fprintf(cmd.OutOrStderr(), "Linking #%d...\n", sub.Number)
+			if _, _, err := addSubIssue(client, parent.ID, sub.ID); err != nil {
+				failures++
+				result.SubIssues = append(result.SubIssues, failedIssueResult(item.Ref, sub, err))
+				continue
+			}
+			subResult.Status = statusAdded
+			result.SubIssues = append(result.SubIssues, subResult)
+			continue
+		}
+
+		fmt.Fprintf(cmd.OutOrStderr(), "Creating '%s'...\n", item.Text)
+		created, err := createSubIssueWithMetadata(io.Discard, client, parentRef.Owner, parentRef.Repo, repoID, parent.ID, &newSubIssue{Title: item.Text})
+		if err != nil {
+			failures++
+			result.SubIssues = append(result.SubIssues, IssueResult{
+				Title:      item.Text,
+				Repository: fmt.Sprintf("%s/%s", parentRef.Owner, parentRef.Repo),
+				Status:     statusFailed,
+				Error:      err.Error(),
+			})
+			continue
+		}
+
+		if item.Checked {
+			if err := closeIssue(client, created.ID, "COMPLETED"); err != nil {
+				warnf("#%d was created but could not be closed: %v", created.Number, err)
+			}
+		}
+
+		subRef := &IssueReference{Owner: parentRef.Owner, Repo: parentRef.Repo, Number: created.Number}
+		subResult := newIssueResult(subRef, created)
+		subResult.Title = item.Text
+		subResult.Status = statusCreated
+		result.SubIssues = append(result.SubIssues, subResult)
+
+		// Keep the item text after the reference
+		if dryRunFlag {
+			replacements[item.Line] = taskReferenceText(created.ID, item.Text)
+		} else {
+			replacements[item.Line] = taskReferenceText(fmt.Sprintf("#%d", created.Number), item.Text)
+		}
+	}
+
+	// Point the task list at the new sub-issues. Without that, running convert again would
+	// create the same issues a second time.
+	var bodyErr error
+	if len(replacements) > 0 {
+		fmt.Fprintf(cmd.OutOrStderr(), "Updating task list of #%d...\n", parentRef.Number)
+		if err := updateIssueBody(client, parent.ID, rewriteTasklist(body, replacements)); err != nil {
+			var created []string
+			for _, sub := range result.SubIssues {
+				if sub.Status == statusCreated {
+					created = append(created, fmt.Sprintf("#%d", sub.Number))
+				}
+			}
+			bodyErr = fmt.Errorf("created %s but could not update the task list of #%d, replace those items with the issue references before converting again: %w",
+				strings.Join(created, ", "), parentRef.Number, err)
+		}
+	}
+
+	if dryRunFlag {
+		return writePlan(cmd.OutOrStdout(), convertJSONFlag || !term.IsTerminal(os.Stdout))
+	}
+
+	if convertJSONFlag {
+		if err := writeMutationResult(cmd.OutOrStdout(), result); err != nil {
+			return err
+		}
+	} else {
+		for _, sub := range result.SubIssues {
+			switch sub.Status {
+			case statusCreated:
+				fmt.Fprintf(cmd.OutOrStdout(), "✓ Created sub-issue #%d: %s\n", sub.Number, sub.Title)
+			case statusAdded:
+				fmt.Fprintf(cmd.OutOrStdout(), "✓ Linked #%d: %s\n", sub.Number, sub.Title)
+			case statusExists:
+				fmt.Fprintf(cmd.OutOrStdout(), "- #%d is already a sub-issue\n", sub.Number)
+			case statusFailed:
+				fmt.Fprintf(cmd.OutOrStderr(), "✗ %s: %s\n", sub.Title, sub.Error)
+			}
+		}
+	}
+
+	if bodyErr != nil {
+		return bodyErr
+	}
+	if failures > 0 {
+		return fmt.Errorf("%d of %d task list items could not be converted", failures, len(items))
+	}
+	return nil
+}
+
+// getIssueBody gets the body of an issue by node ID
+func getIssueBody(client *api.GraphQLClient, issueID string) (string, error) {
+	query := `
+		query($id: ID!) {
+			node(id: $id) {
+				... on Issue {
+					body
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"id": issueID,
+	}
+
+	var response struct {
+		Node struct {
+			Body string `json:"body"`
+		} `json:"node"`
+	}
+
+	err := client.Do(query, variables, &response)
+	if err != nil {
+		return "", fmt.Errorf("failed to get issue body: %w", err)
+	}
+
+	return response.Node.Body, nil
+}
+
+// updateIssueBody replaces the body of an issue
+func updateIssueBody(client *api.GraphQLClient, issueID, body string) error {
+	mutation := `
+		mutation UpdateIssue($id: ID!, $body: String!) {
+			updateIssue(input: {id: $id, body: $body}) {
+				issue {
+					number
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"id":   issueID,
+		"body": body,
+	}
+
+	var response struct {
+		UpdateIssue struct {
+			Issue struct {
+				Number int `json:"number"`
+			} `json:"issue"`
+		} `json:"updateIssue"`
+	}
+
+	err := doMutation(client, "updateIssue", "Update issue body", mutation, variables, &response)
+	if err != nil {
+		return fmt.Errorf("failed to update issue body: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTasklist(t *testing.T) {
+	body := "## Tasks\r\n" +
+		"- [ ] Write docs\r\n" +
+		"- [x] Design API\n" +
+		"  * [X] #456\n" +
+		"- [ ] other/repo#7\n" +
+		"- [ ] https://github.com/owner/repo/issues/8\n" +
+		"- plain bullet\n" +
+		"- [ ]\n" +
+		"```\n" +
+		"- [ ] inside a code block\n" +
+		"```\n"
+
+	items := parseTasklist(body, "owner", "repo")
+	assert.Len(t, items, 5)
+
+	assert.Equal(t, TaskItem{Line: 1, Text: "Write docs"}, items[0])
+	assert.Equal(t, TaskItem{Line: 2, Checked: true, Text: "Design API"}, items[1])
+	assert.True(t, items[2].Checked)
+	assert.Equal(t, &IssueReference{Owner: "owner", Repo: "repo", Number: 456}, items[2].Ref)
+	assert.Equal(t, &IssueReference{Owner: "other", Repo: "repo", Number: 7}, items[3].Ref)
+	assert.Equal(t, &IssueReference{Owner: "owner", Repo: "repo", Number: 8}, items[4].Ref)
+}
+
+func TestParseTaskReference(t *testing.T) {
+	tests := []struct {
+		text string
+		want *IssueReference
+	}{
+		{"#12", &IssueReference{Owner: "o", Repo: "r", Number: 12}},
+		{"cli/cli#3", &IssueReference{Owner: "cli", Repo: "cli", Number: 3}},
+		{"#12 — Write docs", &IssueReference{Owner: "o", Repo: "r", Number: 12}},
+		{"https://github.com/o/r/issues/12 — Write docs", &IssueReference{Owner: "o", Repo: "r", Number: 12}},
+		{"#1 priority: fix login", nil},
+		{"cli/cli#3 needs a follow-up", nil},
+		{"https://github.com/o/r/issues/12 is broken", nil},
+		{"Fix #12 later", nil},
+		{"#12-later", nil},
+		{"#0", nil},
+		{"https://github.com/o/r/pull/5", nil},
+		{"Write docs", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseTaskReference(tt.text, "o", "r"))
+		})
+	}
+}
+
+func TestParseTasklistFreeText(t *testing.T) {
+	// An item that starts with a number is created, not linked to that issue
+	items := parseTasklist("- [ ] #1 priority: fix login\n", "owner", "repo")
+	assert.Len(t, items, 1)
+	assert.Nil(t, items[0].Ref)
+	assert.Equal(t, "#1 priority: fix login", items[0].Text)
+
+	// Its text is kept when the item is rewritten, and the result is a reference
+	got := rewriteTasklist("- [ ] #1 priority: fix login\n", map[int]string{0: taskReferenceText("#12", items[0].Text)})
+	assert.Equal(t, "- [ ] #12 — #1 priority: fix login\n", got)
+	assert.Equal(t, &IssueReference{Owner: "owner", Repo: "repo", Number: 12}, parseTasklist(got, "owner", "repo")[0].Ref)
+}
+
+func TestRewriteTasklist(t *testing.T) {
+	body := "Intro\n- [ ] Write docs\n  * [x] Design API\n- [ ] #456\n"
+
+	got := rewriteTasklist(body, map[int]string{
+		1: taskReferenceText("#10", "Write docs"),
+		2: taskReferenceText("#11", "Design API"),
+		9: "#99",
+	})
+	assert.Equal(t, "Intro\n- [ ] #10 — Write docs\n  * [x] #11 — Design API\n- [ ] #456\n", got)
+
+	// Rewritten items are recognized as references on the next run
+	items := parseTasklist(got, "owner", "repo")
+	assert.Equal(t, &IssueReference{Owner: "owner", Repo: "repo", Number: 10}, items[0].Ref)
+	assert.Equal(t, &IssueReference{Owner: "owner", Repo: "repo", Number: 11}, items[1].Ref)
+
+	// Windows line endings are kept
+	got = rewriteTasklist("- [ ] Write docs\r\nOutro", map[int]string{0: "#10"})
+	assert.Equal(t, "- [ ] #10\r\nOutro", got)
+}
@@ -3,9 +3,14 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/prompter"
+	"github.com/cli/go-gh/v2/pkg/term"
 	"github.com/spf13/cobra"
 )
 
@@ -18,6 +23,14 @@ var (
 	milestoneFlag  string
 	projectsFlag   []string  // Changed to support multiple projects
 	createRepoFlag string
+	createJSONFlag bool
+	inheritFlag    []string
+	fieldsFlag     []string
+	typeFlag       string
+	bodyFileFlag   string
+	editorFlag     bool
+	templateFlag   string
+	fromFileFlag   string
 )
 
 var createCmd = &cobra.Command{
@@ -29,12 +42,28 @@ Examples:
   # Create with minimal options
   gh sub-issue create --parent 123 --title "Implement feature X"
   
+  # Interactive: prompts for parent, title, body and metadata
+  gh sub-issue create
+  
   # Create with body text
   gh sub-issue create --parent 123 --title "Bug fix" --body "Description of the issue"
   
+  # Read a long body from a file or standard input
+  gh sub-issue create --parent 123 --title "Spec" --body-file spec.md
+  cat spec.md | gh sub-issue create --parent 123 --title "Spec" --body-file -
+  
+  # Write title and body in your editor (first line is the title)
+  gh sub-issue create --parent 123 --editor
+  
+  # Use a repository issue template or issue form (labels, assignees, title prefix and body)
+  gh sub-issue create --parent 123 --title "Crash on start" --template "Bug report"
+  
   # Create with labels and assignees
   gh sub-issue create --parent 123 --title "Task" --label bug --label priority --assignee username
   
+  # Set the issue type
+  gh sub-issue create --parent 123 --title "Task" --type Task
+  
   # Add to a single project
   gh sub-issue create --parent 123 --title "Task" --project "Roadmap"
   
@@ -45,24 +74,54 @@ Examples:
   gh sub-issue create --parent https://github.com/owner/repo/issues/123 --title "Sub-task"
   
   # Specify repository for new issue
-  gh sub-issue create --parent 123 --title "Task" --repo owner/repo`,
+  gh sub-issue create --parent 123 --title "Task" --repo owner/repo
+  
+  # Inherit milestone, projects, labels and assignees from the parent
+  gh sub-issue create --parent 123 --title "Task" --inherit
+  
+  # Inherit only some attributes and add an extra label
+  gh sub-issue create --parent 123 --title "Task" --inherit=milestone,projects --label backend
+  
+  # Set project fields on the new project item
+  gh sub-issue create --parent 123 --title "Task" --project "Roadmap" --field "Roadmap:Status=In Progress" --field "Estimate=3"
+  
+  # Create many sub-issues at once: one title per line, or YAML with metadata and children
+  gh sub-issue create --parent 123 --from-file tasks.txt
+  gh sub-issue create --parent 123 --from-file tasks.yaml --label backend
+  
+  # Capture the new issue number in a script
+  gh sub-issue create --parent 123 --title "Task" --json | jq '.subIssues[0].number'`,
+	// Rejects "--inherit labels", which would otherwise inherit everything and drop "labels"
+	Args: cobra.NoArgs,
 	RunE: runCreate,
 }
 
 func init() {
 	rootCmd.AddCommand(createCmd)
 	
-	createCmd.Flags().StringVarP(&parentFlag, "parent", "p", "", "Parent issue number or URL (required)")
-	createCmd.Flags().StringVarP(&titleFlag, "title", "t", "", "Title for the new sub-issue (required)")
+	createCmd.Flags().StringVarP(&parentFlag, "parent", "p", "", "Parent issue number or URL (prompted for when interactive)")
+	createCmd.Flags().StringVarP(&titleFlag, "title", "t", "", "Title for the new sub-issue (required unless --editor)")
 	createCmd.Flags().StringVarP(&bodyFlag, "body", "b", "", "Body text for the new sub-issue")
+	createCmd.Flags().StringVarP(&bodyFileFlag, "body-file", "F", "", "Read body text from file (use \"-\" to read from standard input)")
+	createCmd.Flags().BoolVarP(&editorFlag, "editor", "e", false, "Write the title and body in $GH_EDITOR or $EDITOR")
+	createCmd.Flags().StringVarP(&templateFlag, "template", "T", "", "Start from an issue template or issue form of the repository")
 	createCmd.Flags().StringSliceVarP(&labelsFlag, "label", "l", []string{}, "Add labels to the issue")
 	createCmd.Flags().StringSliceVarP(&assigneesFlag, "assignee", "a", []string{}, "Assign users to the issue")
 	createCmd.Flags().StringVarP(&milestoneFlag, "milestone", "m", "", "Set milestone for the issue")
+	createCmd.Flags().StringVar(&typeFlag, "type", "", "Set the issue type (e.g. Epic, Feature, Task, Bug)")
 	createCmd.Flags().StringSliceVar(&projectsFlag, "project", []string{}, "Add issue to projects (can specify multiple times)")
 	createCmd.Flags().StringVarP(&createRepoFlag, "repo", "R", "", "Repository for the new issue in OWNER/REPO format")
+	createCmd.Flags().BoolVar(&createJSONFlag, "json", false, "Output the result as JSON")
+	createCmd.Flags().StringSliceVar(&inheritFlag, "inherit", nil, "Inherit attributes from the parent, e.g. --inherit=labels,milestone: {labels|milestone|projects|assignees} (default all)")
+	createCmd.Flags().Lookup("inherit").NoOptDefVal = strings.Join(inheritableAttributes, ",")
+	createCmd.Flags().StringArrayVar(&fieldsFlag, "field", []string{}, "Set a project field: [PROJECT:]FIELD=VALUE (can specify multiple times)")
+	
+	createCmd.Flags().StringVar(&fromFileFlag, "from-file", "", "Create a sub-issue per line of a text file, or per item of a YAML file")
 	
-	createCmd.MarkFlagRequired("parent")
-	createCmd.MarkFlagRequired("title")
+	createCmd.MarkFlagsMutuallyExclusive("body", "body-file", "template")
+	for _, flag := range []string{"title", "body", "body-file", "editor", "template"} {
+		createCmd.MarkFlagsMutuallyExclusive("from-file", flag)
+	}
 }
 
 // getRepositoryID gets the GraphQL node ID for a repository
@@ -97,47 +156,81 @@ func getRepositoryID(client *api.GraphQLClient, owner, repo string) (string, err
 	return response.Repository.ID, nil
 }
 
-// getLabelIDs gets the GraphQL node IDs for labels
-func getLabelIDs(client *api.GraphQLClient, owner, repo string, labels []string) ([]string, error) {
-	if len(labels) == 0 {
-		return nil, nil
-	}
-	
+// NamedNode is a GraphQL node identified by its name
+type NamedNode struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// listLabels gets all labels of a repository
+func listLabels(client *api.GraphQLClient, owner, repo string) ([]NamedNode, error) {
 	query := `
-		query($owner: String!, $repo: String!) {
+		query($owner: String!, $repo: String!, $cursor: String) {
 			repository(owner: $owner, name: $repo) {
-				labels(first: 100) {
+				labels(first: 100, after: $cursor) {
 					nodes {
 						id
 						name
 					}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
 				}
 			}
 		}`
 	
-	variables := map[string]interface{}{
-		"owner": owner,
-		"repo":  repo,
+	var labels []NamedNode
+	var cursor *string
+	for {
+		variables := map[string]interface{}{
+			"owner":  owner,
+			"repo":   repo,
+			"cursor": cursor,
+		}
+		
+		var response struct {
+			Repository struct {
+				Labels struct {
+					Nodes    []NamedNode `json:"nodes"`
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+				} `json:"labels"`
+			} `json:"repository"`
+		}
+		
+		err := client.Do(query, variables, &response)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get labels: %w", err)
+		}
+		
+		page := response.Repository.Labels
+		labels = append(labels, page.Nodes...)
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+		endCursor := page.PageInfo.EndCursor
+		cursor = &endCursor
 	}
 	
-	var response struct {
-		Repository struct {
-			Labels struct {
-				Nodes []struct {
-					ID   string `json:"id"`
-					Name string `json:"name"`
-				} `json:"nodes"`
-			} `json:"labels"`
-		} `json:"repository"`
+	return labels, nil
+}
+
+// getLabelIDs gets the GraphQL node IDs for labels
+func getLabelIDs(client *api.GraphQLClient, owner, repo string, labels []string) ([]string, error) {
+	if len(labels) == 0 {
+		return nil, nil
 	}
 	
-	err := client.Do(query, variables, &response)
+	repoLabels, err := listLabels(client, owner, repo)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get labels: %w", err)
+		return nil, err
 	}
 	
 	labelMap := make(map[string]string)
-	for _, label := range response.Repository.Labels.Nodes {
+	for _, label := range repoLabels {
 		labelMap[strings.ToLower(label.Name)] = label.ID
 	}
 	
@@ -146,7 +239,7 @@ func getLabelIDs(client *api.GraphQLClient, owner, repo string, labels []string)
 		if id, ok := labelMap[strings.ToLower(labelName)]; ok {
 			labelIDs = append(labelIDs, id)
 		} else {
-			fmt.Printf("Warning: label '%s' not found in repository\n", labelName)
+			warnf("label '%s' not found in repository", labelName)
 		}
 	}
 	
@@ -180,7 +273,7 @@ func getUserIDs(client *api.GraphQLClient, usernames []string) ([]string, error)
 		
 		err := client.Do(query, variables, &response)
 		if err != nil {
-			fmt.Printf("Warning: user '%s' not found\n", username)
+			warnf("user '%s' not found", username)
 			continue
 		}
 		
@@ -192,19 +285,103 @@ func getUserIDs(client *api.GraphQLClient, usernames []string) ([]string, error)
 	return userIDs, nil
 }
 
+// listMilestones gets the open milestones of a repository, and the closed ones too when includeClosed is set
+func listMilestones(client *api.GraphQLClient, owner, repo string, includeClosed bool) ([]NamedNode, error) {
+	query := `
+		query($owner: String!, $repo: String!, $states: [MilestoneState!]) {
+			repository(owner: $owner, name: $repo) {
+				milestones(first: 100, states: $states) {
+					nodes {
+						id
+						title
+					}
+				}
+			}
+		}`
+	
+	states := []string{"OPEN"}
+	if includeClosed {
+		states = append(states, "CLOSED")
+	}
+	
+	variables := map[string]interface{}{
+		"owner":  owner,
+		"repo":   repo,
+		"states": states,
+	}
+	
+	var response struct {
+		Repository struct {
+			Milestones struct {
+				Nodes []struct {
+					ID    string `json:"id"`
+					Title string `json:"title"`
+				} `json:"nodes"`
+			} `json:"milestones"`
+		} `json:"repository"`
+	}
+	
+	err := client.Do(query, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get milestones: %w", err)
+	}
+	
+	var milestones []NamedNode
+	for _, m := range response.Repository.Milestones.Nodes {
+		milestones = append(milestones, NamedNode{ID: m.ID, Name: m.Title})
+	}
+	return milestones, nil
+}
+
 // getMilestoneID gets the GraphQL node ID for a milestone
 func getMilestoneID(client *api.GraphQLClient, owner, repo, milestone string) (string, error) {
 	if milestone == "" {
 		return "", nil
 	}
 	
+	milestones, err := listMilestones(client, owner, repo, false)
+	if err != nil {
+		return "", err
+	}
+	
+	for _, m := range milestones {
+		if strings.EqualFold(m.Name, milestone) {
+			return m.ID, nil
+		}
+	}
+	
+	warnf("milestone '%s' not found", milestone)
+	return "", nil
+}
+
+// findMilestoneID gets the GraphQL node ID for an open or closed milestone, or "" when there is none
+func findMilestoneID(client *api.GraphQLClient, owner, repo, milestone string) (string, error) {
+	milestones, err := listMilestones(client, owner, repo, true)
+	if err != nil {
+		return "", err
+	}
+	
+	for _, m := range milestones {
+		if strings.EqualFold(m.Name, milestone) {
+			return m.ID, nil
+		}
+	}
+	return "", nil
+}
+
+// getIssueTypeID gets the GraphQL node ID for an issue type of the repository's organization
+func getIssueTypeID(client *api.GraphQLClient, owner, repo, issueType string) (string, error) {
+	if issueType == "" {
+		return "", nil
+	}
+	
 	query := `
 		query($owner: String!, $repo: String!) {
 			repository(owner: $owner, name: $repo) {
-				milestones(first: 100, states: OPEN) {
+				issueTypes(first: 100) {
 					nodes {
 						id
-						title
+						name
 					}
 				}
 			}
@@ -217,37 +394,50 @@ func getMilestoneID(client *api.GraphQLClient, owner, repo, milestone string) (s
 	
 	var response struct {
 		Repository struct {
-			Milestones struct {
+			IssueTypes struct {
 				Nodes []struct {
-					ID    string `json:"id"`
-					Title string `json:"title"`
+					ID   string `json:"id"`
+					Name string `json:"name"`
 				} `json:"nodes"`
-			} `json:"milestones"`
+			} `json:"issueTypes"`
 		} `json:"repository"`
 	}
 	
 	err := client.Do(query, variables, &response)
 	if err != nil {
-		return "", fmt.Errorf("failed to get milestones: %w", err)
+		return "", fmt.Errorf("failed to get issue types: %w", err)
 	}
 	
-	for _, m := range response.Repository.Milestones.Nodes {
-		if strings.EqualFold(m.Title, milestone) {
-			return m.ID, nil
+	var names []string
+	for _, t := range response.Repository.IssueTypes.Nodes {
+		if strings.EqualFold(t.Name, issueType) {
+			return t.ID, nil
 		}
+		names = append(names, t.Name)
 	}
 	
-	fmt.Printf("Warning: milestone '%s' not found\n", milestone)
+	if len(names) == 0 {
+		warnf("issue type '%s' not found (issue types are not available for %s/%s)", issueType, owner, repo)
+	} else {
+		warnf("issue type '%s' not found (available: %s)", issueType, strings.Join(names, ", "))
+	}
 	return "", nil
 }
 
-// getProjectV2ID gets the GraphQL node ID for a ProjectV2
-func getProjectV2ID(client *api.GraphQLClient, owner, repo, project string) (string, error) {
-	if project == "" {
-		return "", nil
+// listProjectsV2 gets the ProjectV2 boards of a repository and of its owning user or organization
+func listProjectsV2(client *api.GraphQLClient, owner, repo string) ([]ProjectRef, error) {
+	var projects []ProjectRef
+	seen := make(map[string]bool)
+	collect := func(nodes []ProjectRef) {
+		for _, p := range nodes {
+			if p.ID != "" && !seen[p.ID] {
+				seen[p.ID] = true
+				projects = append(projects, p)
+			}
+		}
 	}
 	
-	// First, try to find project in repository
+	// First, repository projects
 	repoQuery := `
 		query($owner: String!, $repo: String!) {
 			repository(owner: $owner, name: $repo) {
@@ -269,26 +459,17 @@ func getProjectV2ID(client *api.GraphQLClient, owner, repo, project string) (str
 	var repoResponse struct {
 		Repository struct {
 			ProjectsV2 struct {
-				Nodes []struct {
-					ID     string `json:"id"`
-					Title  string `json:"title"`
-					Number int    `json:"number"`
-				} `json:"nodes"`
+				Nodes []ProjectRef `json:"nodes"`
 			} `json:"projectsV2"`
 		} `json:"repository"`
 	}
 	
 	err := client.Do(repoQuery, variables, &repoResponse)
 	if err == nil {
-		// Check by title or number
-		for _, p := range repoResponse.Repository.ProjectsV2.Nodes {
-			if strings.EqualFold(p.Title, project) || fmt.Sprint(p.Number) == project {
-				return p.ID, nil
-			}
-		}
+		collect(repoResponse.Repository.ProjectsV2.Nodes)
 	}
 	
-	// Try user-level projects
+	// Then user-level projects
 	userQuery := `
 		query($login: String!) {
 			user(login: $login) {
@@ -309,25 +490,17 @@ func getProjectV2ID(client *api.GraphQLClient, owner, repo, project string) (str
 	var userResponse struct {
 		User struct {
 			ProjectsV2 struct {
-				Nodes []struct {
-					ID     string `json:"id"`
-					Title  string `json:"title"`
-					Number int    `json:"number"`
-				} `json:"nodes"`
+				Nodes []ProjectRef `json:"nodes"`
 			} `json:"projectsV2"`
 		} `json:"user"`
 	}
 	
 	err = client.Do(userQuery, userVars, &userResponse)
 	if err == nil {
-		for _, p := range userResponse.User.ProjectsV2.Nodes {
-			if strings.EqualFold(p.Title, project) || fmt.Sprint(p.Number) == project {
-				return p.ID, nil
-			}
-		}
+		collect(userResponse.User.ProjectsV2.Nodes)
 	}
 	
-	// Try organization-level projects
+	// Finally organization-level projects
 	orgQuery := `
 		query($login: String!) {
 			organization(login: $login) {
@@ -344,32 +517,46 @@ func getProjectV2ID(client *api.GraphQLClient, owner, repo, project string) (str
 	var orgResponse struct {
 		Organization struct {
 			ProjectsV2 struct {
-				Nodes []struct {
-					ID     string `json:"id"`
-					Title  string `json:"title"`
-					Number int    `json:"number"`
-				} `json:"nodes"`
+				Nodes []ProjectRef `json:"nodes"`
 			} `json:"projectsV2"`
 		} `json:"organization"`
 	}
 	
 	err = client.Do(orgQuery, userVars, &orgResponse)
 	if err == nil {
-		for _, p := range orgResponse.Organization.ProjectsV2.Nodes {
-			if strings.EqualFold(p.Title, project) || fmt.Sprint(p.Number) == project {
-				return p.ID, nil
-			}
+		collect(orgResponse.Organization.ProjectsV2.Nodes)
+	}
+	
+	return projects, nil
+}
+
+// getProjectV2ID gets the GraphQL node ID for a ProjectV2
+func getProjectV2ID(client *api.GraphQLClient, owner, repo, project string) (string, error) {
+	if project == "" {
+		return "", nil
+	}
+	
+	projects, err := listProjectsV2(client, owner, repo)
+	if err != nil {
+		return "", err
+	}
+	
+	// Check by title or number
+	for _, p := range projects {
+		if strings.EqualFold(p.Title, project) || fmt.Sprint(p.Number) == project {
+			return p.ID, nil
 		}
 	}
 	
-	fmt.Printf("Warning: project '%s' not found\n", project)
+	warnf("project '%s' not found", project)
 	return "", nil
 }
 
 // assignToProjectV2 assigns an issue to a ProjectV2 using the addProjectV2ItemById mutation
-func assignToProjectV2(client *api.GraphQLClient, projectID, issueID string) error {
+// and returns the ID of the new project item
+func assignToProjectV2(client *api.GraphQLClient, projectID, issueID string) (string, error) {
 	if projectID == "" || issueID == "" {
-		return nil
+		return "", nil
 	}
 	
 	mutation := `
@@ -394,12 +581,16 @@ func assignToProjectV2(client *api.GraphQLClient, projectID, issueID string) err
 		} `json:"addProjectV2ItemById"`
 	}
 	
-	err := client.Do(mutation, variables, &response)
+	err := doMutation(client, "addProjectV2ItemById", "Add issue to project", mutation, variables, &response)
 	if err != nil {
-		return fmt.Errorf("failed to add issue to project: %w", err)
+		return "", fmt.Errorf("failed to add issue to project: %w", err)
 	}
 	
-	return nil
+	if dryRunFlag {
+		return plannedID(), nil
+	}
+	
+	return response.AddProjectV2ItemById.Item.ID, nil
 }
 
 // createSubIssue creates a new issue with a parent issue
@@ -431,14 +622,186 @@ func createSubIssue(client *api.GraphQLClient, input map[string]interface{}) (in
 		} `json:"createIssue"`
 	}
 	
-	err := client.Do(mutation, variables, &response)
+	description := fmt.Sprintf("Create issue %q", input["title"])
+	err := doMutation(client, "createIssue", description, mutation, variables, &response)
 	if err != nil {
 		return 0, "", "", fmt.Errorf("failed to create sub-issue: %w", err)
 	}
 	
+	if dryRunFlag {
+		return 0, "", plannedID(), nil
+	}
+	
 	return response.CreateIssue.Issue.Number, response.CreateIssue.Issue.URL, response.CreateIssue.Issue.ID, nil
 }
 
+// newSubIssue is the content and metadata of a sub-issue to create
+type newSubIssue struct {
+	Title     string
+	Body      string
+	Labels    []string
+	Assignees []string
+	Milestone string
+	Type      string
+	Projects  []string
+	// InheritedProjects are already resolved and added after Projects
+	InheritedProjects []ProjectRef
+	Fields            []FieldSpec
+}
+
+// createSubIssueWithMetadata resolves the metadata of a new issue in owner/repo, creates it
+// under parentID (a top-level issue when empty) and adds it to its projects. Progress
+// messages are written to w.
+func createSubIssueWithMetadata(w io.Writer, client *api.GraphQLClient, owner, repo, repoID, parentID string, issue *newSubIssue) (*IssueInfo, error) {
+	// Build the mutation input
+	input := map[string]interface{}{
+		"repositoryId": repoID,
+		"title":        issue.Title,
+	}
+	if parentID != "" {
+		input["parentIssueId"] = parentID
+	}
+	
+	if issue.Body != "" {
+		input["body"] = issue.Body
+	}
+	
+	// Get label IDs if specified
+	if len(issue.Labels) > 0 {
+		fmt.Fprintf(w, "Getting label IDs...\n")
+		labelIDs, err := getLabelIDs(client, owner, repo, issue.Labels)
+		if err != nil {
+			return nil, err
+		}
+		if len(labelIDs) > 0 {
+			input["labelIds"] = labelIDs
+		}
+	}
+	
+	// Get assignee IDs if specified
+	if len(issue.Assignees) > 0 {
+		fmt.Fprintf(w, "Getting assignee IDs...\n")
+		assigneeIDs, err := getUserIDs(client, issue.Assignees)
+		if err != nil {
+			return nil, err
+		}
+		if len(assigneeIDs) > 0 {
+			input["assigneeIds"] = assigneeIDs
+		}
+	}
+	
+	// Get milestone ID if specified
+	if issue.Milestone != "" {
+		fmt.Fprintf(w, "Getting milestone ID...\n")
+		milestoneID, err := getMilestoneID(client, owner, repo, issue.Milestone)
+		if err != nil {
+			return nil, err
+		}
+		if milestoneID != "" {
+			input["milestoneId"] = milestoneID
+		}
+	}
+	
+	// Get issue type ID if specified
+	if issue.Type != "" {
+		fmt.Fprintf(w, "Getting issue type ID...\n")
+		issueTypeID, err := getIssueTypeID(client, owner, repo, issue.Type)
+		if err != nil {
+			return nil, err
+		}
+		if issueTypeID != "" {
+			input["issueTypeId"] = issueTypeID
+		}
+	}
+	
+	// Get project IDs if specified (will be assigned after issue creation)
+	var projectIDs, projectNames []string
+	if len(issue.Projects) > 0 {
+		fmt.Fprintf(w, "Getting project IDs...\n")
+		for _, project := range issue.Projects {
+			projectID, err := getProjectV2ID(client, owner, repo, project)
+			if err != nil {
+				return nil, err
+			}
+			if projectID != "" {
+				projectIDs = append(projectIDs, projectID)
+				projectNames = append(projectNames, project)
+			}
+		}
+	}
+	
+	// Inherited projects are already resolved; skip boards that were also given explicitly
+	for _, project := range issue.InheritedProjects {
+		if !containsID(projectIDs, project.ID) {
+			projectIDs = append(projectIDs, project.ID)
+			projectNames = append(projectNames, project.Title)
+		}
+	}
+	
+	// Resolve custom field values for the projects before creating anything
+	var fieldUpdates []FieldUpdate
+	var err error
+	if len(issue.Fields) > 0 {
+		if len(projectIDs) == 0 {
+			return nil, fmt.Errorf("--field requires the issue to be added to a project (use --project or --inherit)")
+		}
+		fmt.Fprintf(w, "Getting project fields...\n")
+		var projects []*ProjectFields
+		for _, projectID := range projectIDs {
+			project, err := getProjectFields(client, projectID)
+			if err != nil {
+				return nil, err
+			}
+			projects = append(projects, project)
+		}
+		fieldUpdates, err = resolveFieldUpdates(issue.Fields, projects, projectNames, time.Now())
+		if err != nil {
+			return nil, err
+		}
+	}
+	
+	// Create the sub-issue
+	fmt.Fprintf(w, "Creating sub-issue...\n")
+	number, url, issueID, err := createSubIssue(client, input)
+	if err != nil {
+		if strings.Contains(err.Error(), "permission") || strings.Contains(err.Error(), "403") {
+			err = fmt.Errorf("insufficient permissions to create issues in %s/%s",
+				owner, repo)
+		}
+		return nil, err
+	}
+	
+	// Assign to projects if specified
+	itemIDs := make(map[string]string)
+	if len(projectIDs) > 0 {
+		fmt.Fprintf(w, "Assigning issue to projects...\n")
+		for i, projectID := range projectIDs {
+			itemID, err := assignToProjectV2(client, projectID, issueID)
+			if err != nil {
+				warnf("failed to add to project %s: %v", projectNames[i], err)
+				continue
+			}
+			itemIDs[projectID] = itemID
+		}
+	}
+	
+	// Set custom field values on the new project items
+	if len(fieldUpdates) > 0 {
+		fmt.Fprintf(w, "Setting project fields...\n")
+		for _, update := range fieldUpdates {
+			itemID, ok := itemIDs[update.ProjectID]
+			if !ok {
+				continue
+			}
+			if err := updateProjectV2ItemField(client, update, itemID); err != nil {
+				warnf("project %s: %v", update.ProjectTitle, err)
+			}
+		}
+	}
+	
+	return &IssueInfo{ID: issueID, Number: number, Title: issue.Title, URL: url, State: "open"}, nil
+}
+
 func runCreate(cmd *cobra.Command, args []string) error {
 	_ = context.Background() // Reserved for future use
 	
@@ -460,10 +823,84 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		}
 	}
 	
-	// Parse parent issue reference
-	parentRef, err := parseIssueReference(parentFlag, defaultOwner, defaultRepo)
+	// Parse parent issue reference (the wizard asks for it when missing)
+	var parentRef *IssueReference
+	if parentFlag != "" {
+		parentRef, err = parseIssueReference(parentFlag, defaultOwner, defaultRepo)
+		if err != nil {
+			return fmt.Errorf("invalid parent issue: %w", err)
+		}
+	}
+	
+	inherit, err := parseInheritFlag(inheritFlag)
 	if err != nil {
-		return fmt.Errorf("invalid parent issue: %w", err)
+		return err
+	}
+	
+	var fieldSpecs []FieldSpec
+	for _, field := range fieldsFlag {
+		spec, err := parseFieldFlag(field)
+		if err != nil {
+			return err
+		}
+		fieldSpecs = append(fieldSpecs, spec)
+	}
+	
+	var batch []batchItem
+	if fromFileFlag != "" {
+		if parentRef == nil {
+			return fmt.Errorf("--parent is required with --from-file")
+		}
+		batch, err = loadBatchFile(fromFileFlag, cmd.InOrStdin())
+		if err != nil {
+			return err
+		}
+	}
+	
+	// Resolve title and body from --body-file, a template or the editor
+	title, body := titleFlag, bodyFlag
+	labels, assignees, milestone, projects := labelsFlag, assigneesFlag, milestoneFlag, projectsFlag
+	if bodyFileFlag != "" {
+		body, err = readBodyFile(bodyFileFlag, cmd.InOrStdin())
+		if err != nil {
+			return err
+		}
+	}
+	
+	var titlePrefix string
+	if templateFlag != "" {
+		fmt.Fprintf(cmd.OutOrStderr(), "Loading issue template '%s'...\n", templateFlag)
+		tmpl, err := loadIssueTemplate(defaultOwner, defaultRepo, templateFlag, createRepoFlag == "")
+		if err != nil {
+			return err
+		}
+		titlePrefix = tmpl.Title
+		body = tmpl.Body
+		labels = mergeNames(labels, tmpl.Labels)
+		assignees = mergeNames(assignees, tmpl.Assignees)
+	}
+	
+	if editorFlag {
+		content, err := openEditor(editorTemplate(applyTemplateTitle(titlePrefix, title), body))
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(content) == "" {
+			fmt.Fprintln(cmd.OutOrStderr(), "Empty buffer, creation cancelled")
+			return nil
+		}
+		title, body = parseEditorContent(content)
+		titlePrefix = ""
+	}
+	
+	// Prompt for missing values when running interactively, like gh issue create
+	interactive := term.IsTerminal(os.Stdin) && term.IsTerminal(os.Stdout)
+	useWizard := parentRef == nil || (title == "" && batch == nil)
+	if useWizard && !interactive {
+		if parentRef == nil {
+			return fmt.Errorf("--parent is required when not running interactively")
+		}
+		return fmt.Errorf("title is required (use --title or --editor)")
 	}
 	
 	// Create GraphQL client
@@ -472,11 +909,42 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create GitHub client: %w", err)
 	}
 	
+	if useWizard {
+		answers := &createAnswers{
+			Parent:    parentFlag,
+			Title:     title,
+			Body:      body,
+			Labels:    labels,
+			Assignees: assignees,
+			Milestone: milestone,
+			Projects:  projects,
+		}
+		p := prompter.New(os.Stdin, os.Stdout, os.Stderr)
+		source := &apiWizardSource{client: client, owner: defaultOwner, repo: defaultRepo}
+		if err := runCreateWizard(p, source, answers); err != nil {
+			if err == errWizardCancelled {
+				fmt.Fprintln(cmd.OutOrStderr(), "Creation cancelled")
+				return nil
+			}
+			return err
+		}
+		
+		if parentRef == nil {
+			parentRef, err = parseIssueReference(answers.Parent, defaultOwner, defaultRepo)
+			if err != nil {
+				return fmt.Errorf("invalid parent issue: %w", err)
+			}
+		}
+		title, body = answers.Title, answers.Body
+		labels, assignees, milestone, projects = answers.Labels, answers.Assignees, answers.Milestone, answers.Projects
+	}
+	title = applyTemplateTitle(titlePrefix, title)
+	
 	// Get parent issue ID
 	fmt.Fprintf(cmd.OutOrStderr(), "Getting parent issue #%d from %s/%s...\n",
 		parentRef.Number, parentRef.Owner, parentRef.Repo)
 	
-	parentID, err := getIssueNodeID(client, parentRef.Owner, parentRef.Repo, parentRef.Number)
+	parent, err := getIssue(client, parentRef.Owner, parentRef.Repo, parentRef.Number)
 	if err != nil {
 		if strings.Contains(err.Error(), "authentication") || strings.Contains(err.Error(), "401") {
 			return fmt.Errorf("authentication required. Run 'gh auth login' first")
@@ -488,6 +956,17 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	
+	// Merge attributes inherited from the parent with the explicit flags
+	var inheritedProjects []ProjectRef
+	if len(inherit) > 0 {
+		fmt.Fprintf(cmd.OutOrStderr(), "Getting attributes of parent issue #%d...\n", parentRef.Number)
+		attrs, err := getIssueAttributes(client, parentRef.Owner, parentRef.Repo, parentRef.Number)
+		if err != nil {
+			return err
+		}
+		labels, assignees, milestone, inheritedProjects = applyInheritance(inherit, attrs, labels, assignees, milestone)
+	}
+	
 	// Get repository ID for the new issue
 	fmt.Fprintf(cmd.OutOrStderr(), "Getting repository information...\n")
 	repoID, err := getRepositoryID(client, defaultOwner, defaultRepo)
@@ -495,88 +974,56 @@ func runCreate(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	
-	// Build the mutation input
-	input := map[string]interface{}{
-		"repositoryId":  repoID,
-		"title":         titleFlag,
-		"parentIssueId": parentID,
+	// Create the sub-issue with its metadata
+	issue := &newSubIssue{
+		Title:             title,
+		Body:              body,
+		Labels:            labels,
+		Assignees:         assignees,
+		Milestone:         milestone,
+		Type:              typeFlag,
+		Projects:          projects,
+		InheritedProjects: inheritedProjects,
+		Fields:            fieldSpecs,
 	}
-	
-	if bodyFlag != "" {
-		input["body"] = bodyFlag
+	if batch != nil {
+		return runBatchCreate(cmd, client, parentRef, parent, defaultOwner, defaultRepo, repoID, batch, issue)
 	}
-	
-	// Get label IDs if specified
-	if len(labelsFlag) > 0 {
-		fmt.Fprintf(cmd.OutOrStderr(), "Getting label IDs...\n")
-		labelIDs, err := getLabelIDs(client, defaultOwner, defaultRepo, labelsFlag)
-		if err != nil {
-			return err
-		}
-		if len(labelIDs) > 0 {
-			input["labelIds"] = labelIDs
-		}
-	}
-	
-	// Get assignee IDs if specified
-	if len(assigneesFlag) > 0 {
-		fmt.Fprintf(cmd.OutOrStderr(), "Getting assignee IDs...\n")
-		assigneeIDs, err := getUserIDs(client, assigneesFlag)
-		if err != nil {
-			return err
-		}
-		if len(assigneeIDs) > 0 {
-			input["assigneeIds"] = assigneeIDs
-		}
-	}
-	
-	// Get milestone ID if specified
-	if milestoneFlag != "" {
-		fmt.Fprintf(cmd.OutOrStderr(), "Getting milestone ID...\n")
-		milestoneID, err := getMilestoneID(client, defaultOwner, defaultRepo, milestoneFlag)
-		if err != nil {
-			return err
-		}
-		if milestoneID != "" {
-			input["milestoneId"] = milestoneID
-		}
-	}
-	
-	// Get project IDs if specified (will be assigned after issue creation)
-	var projectIDs []string
-	if len(projectsFlag) > 0 {
-		fmt.Fprintf(cmd.OutOrStderr(), "Getting project IDs...\n")
-		for _, project := range projectsFlag {
-			projectID, err := getProjectV2ID(client, defaultOwner, defaultRepo, project)
-			if err != nil {
-				return err
+	created, err := createSubIssueWithMetadata(cmd.OutOrStderr(), client, defaultOwner, defaultRepo, repoID, parent.ID, issue)
+	if err != nil {
+		if createJSONFlag {
+			result := &MutationResult{
+				Parent: newIssueResult(parentRef, parent),
+				SubIssues: []IssueResult{{
+					Repository: fmt.Sprintf("%s/%s", defaultOwner, defaultRepo),
+					Status:     statusFailed,
+					Error:      err.Error(),
+				}},
 			}
-			if projectID != "" {
-				projectIDs = append(projectIDs, projectID)
+			if writeErr := writeMutationResult(cmd.OutOrStdout(), result); writeErr != nil {
+				return writeErr
 			}
 		}
+		return err
 	}
+	number, url, issueID := created.Number, created.URL, created.ID
 	
-	// Create the sub-issue
-	fmt.Fprintf(cmd.OutOrStderr(), "Creating sub-issue...\n")
-	number, url, issueID, err := createSubIssue(client, input)
-	if err != nil {
-		if strings.Contains(err.Error(), "permission") || strings.Contains(err.Error(), "403") {
-			return fmt.Errorf("insufficient permissions to create issues in %s/%s",
-				defaultOwner, defaultRepo)
-		}
-		return err
+	if dryRunFlag {
+		return writePlan(cmd.OutOrStdout(), createJSONFlag || !term.IsTerminal(os.Stdout))
 	}
 	
-	// Assign to projects if specified
-	if len(projectIDs) > 0 {
-		fmt.Fprintf(cmd.OutOrStderr(), "Assigning issue to projects...\n")
-		for i, projectID := range projectIDs {
-			err := assignToProjectV2(client, projectID, issueID)
-			if err != nil {
-				fmt.Fprintf(cmd.OutOrStderr(), "Warning: Failed to add to project %s: %v\n", projectsFlag[i], err)
-			}
+	if createJSONFlag {
+		result := &MutationResult{
+			Parent: newIssueResult(parentRef, parent),
+			SubIssues: []IssueResult{{
+				Number:     number,
+				URL:        url,
+				ID:         issueID,
+				Repository: fmt.Sprintf("%s/%s", defaultOwner, defaultRepo),
+				Status:     statusCreated,
+			}},
 		}
+		return writeMutationResult(cmd.OutOrStdout(), result)
 	}
 	
 	// Success message
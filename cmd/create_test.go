@@ -21,7 +21,7 @@ func TestCreateCmdFlags(t *testing.T) {
 		{
 			name:      "title flag",
 			flagName:  "title",
-			required:  true,
+			required:  false, // Not required with --editor
 			shorthand: "t",
 		},
 		{
@@ -60,6 +60,42 @@ func TestCreateCmdFlags(t *testing.T) {
 			required:  false,
 			shorthand: "", // No shorthand
 		},
+		{
+			name:      "body-file flag",
+			flagName:  "body-file",
+			required:  false,
+			shorthand: "F",
+		},
+		{
+			name:      "editor flag",
+			flagName:  "editor",
+			required:  false,
+			shorthand: "e",
+		},
+		{
+			name:      "template flag",
+			flagName:  "template",
+			required:  false,
+			shorthand: "T",
+		},
+		{
+			name:      "from-file flag",
+			flagName:  "from-file",
+			required:  false,
+			shorthand: "",
+		},
+		{
+			name:      "type flag",
+			flagName:  "type",
+			required:  false,
+			shorthand: "",
+		},
+		{
+			name:      "inherit flag",
+			flagName:  "inherit",
+			required:  false,
+			shorthand: "",
+		},
 	}
 
 	for _, tt := range tests {
@@ -91,6 +127,11 @@ func TestCreateCmdValidation(t *testing.T) {
 		t.Error("title flag not found")
 	}
 	
+	// "--inherit labels" leaves "labels" as an argument, which must be rejected
+	if createCmd.Args == nil || createCmd.Args(createCmd, []string{"labels,milestone"}) == nil {
+		t.Error("create accepts positional arguments")
+	}
+	
 	// Test flag parsing with various combinations
 	tests := []struct {
 		name        string
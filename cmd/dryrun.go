@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// PlannedMutation represents a mutation that would be sent in dry-run mode
+type PlannedMutation struct {
+	Ref         string                 `json:"ref"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Query       string                 `json:"query"`
+	Variables   map[string]interface{} `json:"variables"`
+}
+
+// MutationPlan collects the mutations and warnings of a dry run
+type MutationPlan struct {
+	DryRun    bool              `json:"dryRun"`
+	Mutations []PlannedMutation `json:"mutations"`
+	Warnings  []string          `json:"warnings"`
+}
+
+// plan records everything the current command would change
+var plan = newMutationPlan()
+
+func newMutationPlan() *MutationPlan {
+	return &MutationPlan{
+		DryRun:    true,
+		Mutations: []PlannedMutation{},
+		Warnings:  []string{},
+	}
+}
+
+// doMutation sends a GraphQL mutation, or records it in the plan when --dry-run is set
+func doMutation(client *api.GraphQLClient, name, description, mutation string, variables map[string]interface{}, response interface{}) error {
+	if dryRunFlag {
+		plan.Mutations = append(plan.Mutations, PlannedMutation{
+			Ref:         fmt.Sprintf("%s#%d", name, len(plan.Mutations)+1),
+			Name:        name,
+			Description: description,
+			Query:       strings.TrimSpace(mutation),
+			Variables:   variables,
+		})
+		return nil
+	}
+
+	return client.Do(mutation, variables, response)
+}
+
+// plannedID returns a placeholder for the node ID produced by the last planned mutation
+func plannedID() string {
+	if len(plan.Mutations) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("<%s>", plan.Mutations[len(plan.Mutations)-1].Ref)
+}
+
+// isPlannedID reports whether id is a placeholder returned by plannedID
+func isPlannedID(id string) bool {
+	return strings.HasPrefix(id, "<") && strings.HasSuffix(id, ">")
+}
+
+// warnf prints a warning to stderr and keeps it for the dry-run plan
+func warnf(format string, args ...interface{}) {
+	message := fmt.Sprintf(format, args...)
+	plan.Warnings = append(plan.Warnings, message)
+	fmt.Fprintf(os.Stderr, "Warning: %s\n", message)
+}
+
+// formatPlan formats the dry-run plan for humans
+func formatPlan(p *MutationPlan) string {
+	var output strings.Builder
+
+	output.WriteString("Dry run: no changes were made.\n\n")
+
+	if len(p.Mutations) == 0 {
+		output.WriteString("No mutations would be sent.\n")
+	} else {
+		output.WriteString(fmt.Sprintf("Would send %d mutation(s):\n", len(p.Mutations)))
+		for i, m := range p.Mutations {
+			output.WriteString(fmt.Sprintf("  %d. %s - %s\n", i+1, m.Name, m.Description))
+			variables, err := json.MarshalIndent(m.Variables, "     ", "  ")
+			if err == nil {
+				output.WriteString("     " + string(variables) + "\n")
+			}
+		}
+	}
+
+	if len(p.Warnings) > 0 {
+		output.WriteString("\nWarnings:\n")
+		for _, w := range p.Warnings {
+			output.WriteString(fmt.Sprintf("  - %s\n", w))
+		}
+	}
+
+	return output.String()
+}
+
+// writePlan prints the dry-run plan as text or JSON
+func writePlan(w io.Writer, asJSON bool) error {
+	if !asJSON {
+		fmt.Fprint(w, formatPlan(plan))
+		return nil
+	}
+
+	jsonBytes, err := json.MarshalIndent(plan, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format JSON: %w", err)
+	}
+	fmt.Fprintln(w, string(jsonBytes))
+	return nil
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func withDryRun(t *testing.T) {
+	t.Helper()
+	dryRunFlag = true
+	plan = newMutationPlan()
+	t.Cleanup(func() {
+		dryRunFlag = false
+		plan = newMutationPlan()
+	})
+}
+
+func TestDoMutationDryRun(t *testing.T) {
+	withDryRun(t)
+
+	// No client is needed because nothing is sent in dry-run mode
+	variables := map[string]interface{}{"parentId": "P1", "subIssueId": "S1"}
+	err := doMutation(nil, "addSubIssue", "Link sub-issue", "\n  mutation { addSubIssue }\n", variables, nil)
+	assert.NoError(t, err)
+
+	assert.Len(t, plan.Mutations, 1)
+	assert.Equal(t, "addSubIssue#1", plan.Mutations[0].Ref)
+	assert.Equal(t, "mutation { addSubIssue }", plan.Mutations[0].Query)
+	assert.Equal(t, variables, plan.Mutations[0].Variables)
+	assert.Equal(t, "<addSubIssue#1>", plannedID())
+}
+
+func TestCreateSubIssueDryRun(t *testing.T) {
+	withDryRun(t)
+
+	number, url, id, err := createSubIssue(nil, map[string]interface{}{"title": "Task"})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, number)
+	assert.Equal(t, "", url)
+	assert.Equal(t, "<createIssue#1>", id)
+
+	// The placeholder can be used by follow-up mutations
+	itemID, err := assignToProjectV2(nil, "PROJECT", id)
+	assert.NoError(t, err)
+	assert.Len(t, plan.Mutations, 2)
+	assert.Equal(t, "<createIssue#1>", plan.Mutations[1].Variables["contentId"])
+	assert.Equal(t, "<addProjectV2ItemById#2>", itemID)
+}
+
+func TestFormatPlan(t *testing.T) {
+	p := newMutationPlan()
+	assert.Contains(t, formatPlan(p), "No mutations would be sent")
+
+	p.Mutations = append(p.Mutations, PlannedMutation{
+		Ref:         "removeSubIssue#1",
+		Name:        "removeSubIssue",
+		Description: "Unlink sub-issue from parent issue",
+		Variables:   map[string]interface{}{"subIssueId": "S1"},
+	})
+	p.Warnings = append(p.Warnings, "label 'foo' not found in repository")
+
+	output := formatPlan(p)
+	assert.Contains(t, output, "Dry run: no changes were made.")
+	assert.Contains(t, output, "Would send 1 mutation(s):")
+	assert.Contains(t, output, "1. removeSubIssue - Unlink sub-issue from parent issue")
+	assert.Contains(t, output, `"subIssueId": "S1"`)
+	assert.Contains(t, output, "label 'foo' not found in repository")
+}
+
+func TestWritePlanJSON(t *testing.T) {
+	withDryRun(t)
+	warnf("milestone '%s' not found", "v9")
+
+	var buf bytes.Buffer
+	err := writePlan(&buf, true)
+	assert.NoError(t, err)
+
+	var parsed MutationPlan
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &parsed))
+	assert.True(t, parsed.DryRun)
+	assert.Empty(t, parsed.Mutations)
+	assert.Equal(t, []string{"milestone 'v9' not found"}, parsed.Warnings)
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// readBodyFile reads the issue body from a file, or from stdin when path is "-"
+func readBodyFile(path string, stdin io.Reader) (string, error) {
+	var data []byte
+	var err error
+
+	if path == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read body file: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// editorCommand returns the editor to use, following the same precedence as gh
+func editorCommand() string {
+	for _, env := range []string{"GH_EDITOR", "VISUAL", "EDITOR"} {
+		if editor := os.Getenv(env); editor != "" {
+			return editor
+		}
+	}
+	if runtime.GOOS == "windows" {
+		return "notepad"
+	}
+	return "vi"
+}
+
+// editorTemplate builds the editor buffer: the title on the first line, then the body
+func editorTemplate(title, body string) string {
+	return title + "\n\n" + body
+}
+
+// parseEditorContent splits the edited buffer into title and body like a git commit message
+func parseEditorContent(content string) (string, string) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	title, body, _ := strings.Cut(strings.TrimLeft(content, "\n"), "\n")
+	return strings.TrimSpace(title), strings.TrimSpace(body)
+}
+
+// openEditor opens the user's editor on initial and returns the edited content
+func openEditor(initial string) (string, error) {
+	file, err := os.CreateTemp("", "gh-sub-issue-*.md")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(file.Name())
+
+	if _, err := file.WriteString(initial); err != nil {
+		file.Close()
+		return "", fmt.Errorf("failed to write temporary file: %w", err)
+	}
+	file.Close()
+
+	// The editor may include arguments, e.g. "code --wait"
+	args := strings.Fields(editorCommand())
+	editor := exec.Command(args[0], append(args[1:], file.Name())...)
+	editor.Stdin = os.Stdin
+	editor.Stdout = os.Stdout
+	editor.Stderr = os.Stderr
+	if err := editor.Run(); err != nil {
+		return "", fmt.Errorf("editor %s failed: %w", args[0], err)
+	}
+
+	content, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", fmt.Errorf("failed to read edited file: %w", err)
+	}
+
+	return string(content), nil
+}
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadBodyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "body.md")
+	assert.NoError(t, os.WriteFile(path, []byte("## Spec\n\n```go\nfmt.Println()\n```\n"), 0o644))
+
+	body, err := readBodyFile(path, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "## Spec\n\n```go\nfmt.Println()\n```\n", body)
+
+	body, err = readBodyFile("-", strings.NewReader("from stdin"))
+	assert.NoError(t, err)
+	assert.Equal(t, "from stdin", body)
+
+	_, err = readBodyFile(filepath.Join(t.TempDir(), "missing.md"), nil)
+	assert.Error(t, err)
+}
+
+func TestParseEditorContent(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       string
+		expectedTitle string
+		expectedBody  string
+	}{
+		{
+			name:          "title and body",
+			content:       "Add login endpoint\n\nImplement POST /api/login\n\n## Notes\n- rate limit\n",
+			expectedTitle: "Add login endpoint",
+			expectedBody:  "Implement POST /api/login\n\n## Notes\n- rate limit",
+		},
+		{
+			name:          "title only",
+			content:       "Just a title\n",
+			expectedTitle: "Just a title",
+		},
+		{
+			name:          "leading blank lines and CRLF",
+			content:       "\r\n\r\nTitle\r\n\r\nBody\r\n",
+			expectedTitle: "Title",
+			expectedBody:  "Body",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, body := parseEditorContent(tt.content)
+			assert.Equal(t, tt.expectedTitle, title)
+			assert.Equal(t, tt.expectedBody, body)
+		})
+	}
+}
+
+func TestEditorTemplateRoundTrip(t *testing.T) {
+	title, body := parseEditorContent(editorTemplate("Title", "Line 1\n\n# Heading"))
+	assert.Equal(t, "Title", title)
+	assert.Equal(t, "Line 1\n\n# Heading", body)
+}
+
+func TestEditorCommand(t *testing.T) {
+	t.Setenv("GH_EDITOR", "")
+	t.Setenv("VISUAL", "")
+	t.Setenv("EDITOR", "nano")
+	assert.Equal(t, "nano", editorCommand())
+
+	t.Setenv("GH_EDITOR", "code --wait")
+	assert.Equal(t, "code --wait", editorCommand())
+}
+
+func TestOpenEditor(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as editor")
+	}
+
+	script := filepath.Join(t.TempDir(), "editor.sh")
+	assert.NoError(t, os.WriteFile(script, []byte("#!/bin/sh\nprintf 'Edited title\\n\\nEdited body' > \"$1\"\n"), 0o755))
+	t.Setenv("GH_EDITOR", script)
+
+	content, err := openEditor(editorTemplate("Original", ""))
+	assert.NoError(t, err)
+	assert.Equal(t, "Edited title\n\nEdited body", content)
+}
@@ -0,0 +1,619 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	exportRepoFlag   string
+	exportFormatFlag string
+	exportOutputFlag string
+	exportDepthFlag  int
+	exportFieldsFlag string
+)
+
+// exportOptions are the settings shared by all export formats
+type exportOptions struct {
+	// Owner and Repo are the repository of the root; issues elsewhere are qualified
+	Owner string
+	Repo  string
+
+	// Fields are the columns of tabular formats
+	Fields []string
+}
+
+// exportFields are the columns of csv and markdown exports; the issue fields use the
+// names of list --json
+var exportFields = []string{"path", "depth", "number", "title", "state", "assignees", "labels", "milestone", "url", "type", "repository"}
+
+// exportDefaultFields are the columns exported when --fields is not given
+var exportDefaultFields = []string{"path", "depth", "number", "title", "state", "assignees", "labels", "milestone", "url"}
+
+// tabularExportFormats are the formats that accept --fields
+var tabularExportFormats = map[string]bool{"csv": true, "markdown": true}
+
+// exportFormats renders a tree in each supported format
+var exportFormats = map[string]func(tree *IssueNode, opts exportOptions) (string, error){
+	"mermaid":  exportMermaid,
+	"dot":      exportDOT,
+	"plantuml": exportPlantUML,
+	"csv":      exportCSV,
+	"markdown": exportMarkdown,
+	"yaml":     exportYAML,
+}
+
+var exportCmd = &cobra.Command{
+	Use:   "export <issue>",
+	Short: "Export an issue hierarchy as a diagram or table",
+	Long: `Export an issue and its sub-issues as a Mermaid, Graphviz DOT or PlantUML diagram,
+as CSV or a Markdown table, or as YAML.
+
+Diagram nodes show the issue number and title, are colored by state and link to
+the issue. Issues from other repositories are grouped per repository.
+
+CSV and Markdown have one row per issue with its ancestor path and depth. Choose
+the columns with --fields: path, depth, number, title, state, assignees, labels,
+milestone, url, type and repository.
+
+YAML includes bodies and uses the task list format of create --from-file, so the
+hierarchy can be created again under another parent. Only titles, bodies, labels,
+assignees, milestones, types and children are read back: number, repository, URL
+and state are recorded for reference, and the new issues are created open in a
+single repository. Labels and milestones missing there, and closed milestones,
+are skipped with a warning. Hierarchies deeper than --depth, or with more than
+20 labels or 10 assignees on an issue, are refused.
+
+Examples:
+  # Mermaid flowchart for a README or wiki page
+  gh sub-issue export 123 --format mermaid
+
+  # Render with Graphviz
+  gh sub-issue export 123 --format dot | dot -Tsvg -o epic.svg
+
+  # Only two levels, written to a file
+  gh sub-issue export 123 --format plantuml --depth 2 -o epic.puml
+
+  # Spreadsheet with selected columns
+  gh sub-issue export 123 --format csv --fields path,number,title,state,assignees
+
+  # Copy the hierarchy under another parent
+  gh sub-issue export 123 --format yaml -o epic.yaml
+  gh sub-issue create --parent 456 --from-file epic.yaml`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExport,
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.Flags().StringVarP(&exportFormatFlag, "format", "f", "mermaid", "Output format: {mermaid|dot|plantuml|csv|markdown|yaml}")
+	exportCmd.Flags().StringVarP(&exportOutputFlag, "output", "o", "", "Write to a file instead of stdout")
+	exportCmd.Flags().IntVar(&exportDepthFlag, "depth", maxTreeDepth, "Maximum depth of sub-issues to include")
+	exportCmd.Flags().StringVar(&exportFieldsFlag, "fields", "", "Comma-separated columns for csv and markdown")
+	exportCmd.Flags().StringVarP(&exportRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+}
+
+// exportFormatNames returns the supported formats in alphabetical order
+func exportFormatNames() []string {
+	var names []string
+	for name := range exportFormats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// diagramNode is an issue prepared for a diagram
+type diagramNode struct {
+	*IssueNode
+	ID    string
+	Label string
+}
+
+// diagram holds the nodes of a tree with stable IDs, grouped by repository
+type diagram struct {
+	Nodes  []diagramNode
+	Edges  [][2]string
+	Groups []string
+	ByRepo map[string][]diagramNode
+}
+
+// Clustered reports whether the tree spans several repositories
+func (d *diagram) Clustered() bool {
+	return len(d.Groups) > 1
+}
+
+// newDiagram numbers the issues of the tree in pre-order and groups them by repository
+func newDiagram(tree *IssueNode, opts exportOptions) *diagram {
+	d := &diagram{ByRepo: make(map[string][]diagramNode)}
+	ids := make(map[*IssueNode]string)
+
+	tree.Walk(func(node *IssueNode, depth int) {
+		id := fmt.Sprintf("i%d", len(d.Nodes)+1)
+		ids[node] = id
+
+		label := strings.TrimSpace(node.Reference(opts.Owner, opts.Repo) + " " + truncate(node.Title, 50))
+		dn := diagramNode{IssueNode: node, ID: id, Label: label}
+		d.Nodes = append(d.Nodes, dn)
+
+		repository := node.Repository
+		if repository == "" {
+			repository = opts.Owner + "/" + opts.Repo
+		}
+		if _, ok := d.ByRepo[repository]; !ok {
+			d.Groups = append(d.Groups, repository)
+		}
+		d.ByRepo[repository] = append(d.ByRepo[repository], dn)
+	})
+
+	// Edges in pre-order of their parent, once every node has an ID
+	tree.Walk(func(node *IssueNode, depth int) {
+		for _, child := range node.Children {
+			d.Edges = append(d.Edges, [2]string{ids[node], ids[child]})
+		}
+	})
+	return d
+}
+
+// diagramState returns the style class of an issue: open, closed or notPlanned
+func diagramState(node *IssueNode) string {
+	switch {
+	case node.State == "open":
+		return "open"
+	case node.StateReason == "not_planned":
+		return "notPlanned"
+	default:
+		return "closed"
+	}
+}
+
+// diagramColors are the fill and stroke colors per style class, after GitHub's issue icons
+var diagramColors = map[string][2]string{
+	"open":       {"#dafbe1", "#1a7f37"},
+	"closed":     {"#fbefff", "#8250df"},
+	"notPlanned": {"#f6f8fa", "#59636e"},
+}
+
+// diagramClasses are the style classes in output order
+var diagramClasses = []string{"open", "closed", "notPlanned"}
+
+// exportMermaid renders the tree as a Mermaid flowchart
+func exportMermaid(tree *IssueNode, opts exportOptions) (string, error) {
+	d := newDiagram(tree, opts)
+	var out strings.Builder
+
+	out.WriteString("flowchart TD\n")
+	writeNode := func(indent string, n diagramNode) {
+		label := strings.ReplaceAll(n.Label, `"`, "#quot;")
+		out.WriteString(fmt.Sprintf("%s%s[\"%s\"]\n", indent, n.ID, label))
+	}
+	if d.Clustered() {
+		for i, group := range d.Groups {
+			out.WriteString(fmt.Sprintf("    subgraph repo%d[\"%s\"]\n", i+1, group))
+			for _, n := range d.ByRepo[group] {
+				writeNode("        ", n)
+			}
+			out.WriteString("    end\n")
+		}
+	} else {
+		for _, n := range d.Nodes {
+			writeNode("    ", n)
+		}
+	}
+
+	for _, edge := range d.Edges {
+		out.WriteString(fmt.Sprintf("    %s --> %s\n", edge[0], edge[1]))
+	}
+	for _, n := range d.Nodes {
+		if n.URL != "" {
+			out.WriteString(fmt.Sprintf("    click %s \"%s\" _blank\n", n.ID, n.URL))
+		}
+	}
+
+	for _, class := range diagramClasses {
+		var ids []string
+		for _, n := range d.Nodes {
+			if diagramState(n.IssueNode) == class {
+				ids = append(ids, n.ID)
+			}
+		}
+		if len(ids) == 0 {
+			continue
+		}
+		colors := diagramColors[class]
+		out.WriteString(fmt.Sprintf("    classDef %s fill:%s,stroke:%s\n", class, colors[0], colors[1]))
+		out.WriteString(fmt.Sprintf("    class %s %s\n", strings.Join(ids, ","), class))
+	}
+
+	return out.String(), nil
+}
+
+// exportDOT renders the tree as a Graphviz digraph
+func exportDOT(tree *IssueNode, opts exportOptions) (string, error) {
+	d := newDiagram(tree, opts)
+	var out strings.Builder
+
+	escape := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace
+
+	out.WriteString("digraph issues {\n")
+	out.WriteString("    rankdir=TB;\n")
+	out.WriteString("    node [shape=box, style=\"rounded,filled\", fontname=\"Helvetica\"];\n")
+
+	writeNode := func(indent string, n diagramNode) {
+		colors := diagramColors[diagramState(n.IssueNode)]
+		out.WriteString(fmt.Sprintf("%s%s [label=\"%s\", URL=\"%s\", target=\"_blank\", fillcolor=\"%s\", color=\"%s\"];\n",
+			indent, n.ID, escape(n.Label), escape(n.URL), colors[0], colors[1]))
+	}
+	if d.Clustered() {
+		for i, group := range d.Groups {
+			out.WriteString(fmt.Sprintf("    subgraph cluster_%d {\n", i+1))
+			out.WriteString(fmt.Sprintf("        label=\"%s\";\n", escape(group)))
+			for _, n := range d.ByRepo[group] {
+				writeNode("        ", n)
+			}
+			out.WriteString("    }\n")
+		}
+	} else {
+		for _, n := range d.Nodes {
+			writeNode("    ", n)
+		}
+	}
+
+	for _, edge := range d.Edges {
+		out.WriteString(fmt.Sprintf("    %s -> %s;\n", edge[0], edge[1]))
+	}
+	out.WriteString("}\n")
+
+	return out.String(), nil
+}
+
+// exportPlantUML renders the tree as a PlantUML diagram
+func exportPlantUML(tree *IssueNode, opts exportOptions) (string, error) {
+	d := newDiagram(tree, opts)
+	var out strings.Builder
+
+	escape := strings.NewReplacer(`"`, "'").Replace
+
+	out.WriteString("@startuml\n")
+	out.WriteString("skinparam rectangle {\n    RoundCorner 10\n}\n")
+
+	writeNode := func(indent string, n diagramNode) {
+		colors := diagramColors[diagramState(n.IssueNode)]
+		line := fmt.Sprintf("%srectangle \"%s\" as %s", indent, escape(n.Label), n.ID)
+		if n.URL != "" {
+			line += fmt.Sprintf(" [[%s]]", n.URL)
+		}
+		line += fmt.Sprintf(" #back:%s;line:%s\n", strings.TrimPrefix(colors[0], "#"), strings.TrimPrefix(colors[1], "#"))
+		out.WriteString(line)
+	}
+	if d.Clustered() {
+		for _, group := range d.Groups {
+			out.WriteString(fmt.Sprintf("package \"%s\" {\n", escape(group)))
+			for _, n := range d.ByRepo[group] {
+				writeNode("    ", n)
+			}
+			out.WriteString("}\n")
+		}
+	} else {
+		for _, n := range d.Nodes {
+			writeNode("", n)
+		}
+	}
+
+	for _, edge := range d.Edges {
+		out.WriteString(fmt.Sprintf("%s --> %s\n", edge[0], edge[1]))
+	}
+	out.WriteString("@enduml\n")
+
+	return out.String(), nil
+}
+
+// exportRow is an issue of the tree with its place in the hierarchy
+type exportRow struct {
+	Node  *IssueNode
+	Path  []string
+	Depth int
+}
+
+// exportRows flattens the tree in pre-order; the path holds the references of the ancestors
+func exportRows(tree *IssueNode, opts exportOptions) []exportRow {
+	var rows []exportRow
+	var visit func(node *IssueNode, path []string)
+	visit = func(node *IssueNode, path []string) {
+		rows = append(rows, exportRow{Node: node, Path: path, Depth: len(path)})
+		childPath := append(append([]string{}, path...), node.Reference(opts.Owner, opts.Repo))
+		for _, child := range node.Children {
+			visit(child, childPath)
+		}
+	}
+	visit(tree, nil)
+	return rows
+}
+
+// value returns a column of the row as text
+func (r exportRow) value(field string) string {
+	node := r.Node
+	switch field {
+	case "path":
+		return strings.Join(r.Path, " > ")
+	case "depth":
+		return strconv.Itoa(r.Depth)
+	case "number":
+		return strconv.Itoa(node.Number)
+	case "title":
+		return node.Title
+	case "state":
+		return node.State
+	case "assignees":
+		return strings.Join(node.Assignees, ", ")
+	case "labels":
+		return strings.Join(node.Labels, ", ")
+	case "milestone":
+		if node.Milestone == nil {
+			return ""
+		}
+		return node.Milestone.Title
+	case "url":
+		return node.URL
+	case "type":
+		return node.Type
+	case "repository":
+		return node.Repository
+	}
+	return ""
+}
+
+// parseExportFields splits --fields and checks every column
+func parseExportFields(value string) ([]string, error) {
+	if strings.TrimSpace(value) == "" {
+		return exportDefaultFields, nil
+	}
+
+	var fields []string
+	for _, field := range strings.Split(value, ",") {
+		field = strings.TrimSpace(field)
+		valid := false
+		for _, f := range exportFields {
+			if field == f {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("invalid field: %s. Valid fields are: %s", field, strings.Join(exportFields, ", "))
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// exportCSV renders the tree as CSV with a header row
+func exportCSV(tree *IssueNode, opts exportOptions) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	if err := w.Write(opts.Fields); err != nil {
+		return "", err
+	}
+	for _, row := range exportRows(tree, opts) {
+		var record []string
+		for _, field := range opts.Fields {
+			record = append(record, row.value(field))
+		}
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+
+	return buf.String(), w.Error()
+}
+
+// exportMarkdown renders the tree as a Markdown table
+func exportMarkdown(tree *IssueNode, opts exportOptions) (string, error) {
+	var out strings.Builder
+	escape := strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ").Replace
+
+	out.WriteString("| " + strings.Join(opts.Fields, " | ") + " |\n")
+	out.WriteString("|" + strings.Repeat(" --- |", len(opts.Fields)) + "\n")
+	for _, row := range exportRows(tree, opts) {
+		var cells []string
+		for _, field := range opts.Fields {
+			cells = append(cells, escape(row.value(field)))
+		}
+		out.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+
+	return out.String(), nil
+}
+
+// exportItem is the YAML form of an issue. It extends the items read by
+// create --from-file, which ignores the fields it does not know, such as state.
+type exportItem struct {
+	Title       string       `yaml:"title"`
+	Body        string       `yaml:"body,omitempty"`
+	Labels      []string     `yaml:"labels,omitempty"`
+	Assignees   []string     `yaml:"assignees,omitempty"`
+	Milestone   string       `yaml:"milestone,omitempty"`
+	Type        string       `yaml:"type,omitempty"`
+	Number      int          `yaml:"number"`
+	Repository  string       `yaml:"repository"`
+	URL         string       `yaml:"url"`
+	State       string       `yaml:"state"`
+	StateReason string       `yaml:"stateReason,omitempty"`
+	Children    []exportItem `yaml:"children,omitempty"`
+}
+
+// newExportItem converts a tree into YAML items
+func newExportItem(node *IssueNode) exportItem {
+	item := exportItem{
+		Title:       node.Title,
+		Body:        node.Body,
+		Labels:      node.Labels,
+		Assignees:   node.Assignees,
+		Type:        node.Type,
+		Number:      node.Number,
+		Repository:  node.Repository,
+		URL:         node.URL,
+		State:       node.State,
+		StateReason: node.StateReason,
+	}
+	if node.Milestone != nil {
+		item.Milestone = node.Milestone.Title
+	}
+	for _, child := range node.Children {
+		item.Children = append(item.Children, newExportItem(child))
+	}
+	return item
+}
+
+// exportYAML renders the tree as a YAML task list with a single top-level item
+func exportYAML(tree *IssueNode, opts exportOptions) (string, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode([]exportItem{newExportItem(tree)}); err != nil {
+		return "", fmt.Errorf("failed to format YAML: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return "", fmt.Errorf("failed to format YAML: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// bodyBatchSize is the number of issues whose bodies are fetched in one query
+const bodyBatchSize = 50
+
+// fetchBodies fills in the body of every issue of the tree, with one query per level
+func fetchBodies(client *api.GraphQLClient, tree *IssueNode) error {
+	var levels [][]*IssueNode
+	tree.Walk(func(node *IssueNode, depth int) {
+		if depth == len(levels) {
+			levels = append(levels, nil)
+		}
+		levels[depth] = append(levels[depth], node)
+	})
+
+	for _, level := range levels {
+		for start := 0; start < len(level); start += bodyBatchSize {
+			end := start + bodyBatchSize
+			if end > len(level) {
+				end = len(level)
+			}
+			if err := fetchBodyBatch(client, level[start:end]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fetchBodyBatch fills in the bodies of the given issues with a single aliased query
+func fetchBodyBatch(client *api.GraphQLClient, nodes []*IssueNode) error {
+	var params, fields []string
+	variables := make(map[string]interface{})
+	for i, node := range nodes {
+		params = append(params, fmt.Sprintf("$id%d: ID!", i))
+		fields = append(fields, fmt.Sprintf("n%d: node(id: $id%d) { ... on Issue { body } }", i, i))
+		variables[fmt.Sprintf("id%d", i)] = node.ID
+	}
+	query := fmt.Sprintf("query(%s) {\n%s\n}", strings.Join(params, ", "), strings.Join(fields, "\n"))
+
+	var response map[string]*struct {
+		Body string `json:"body"`
+	}
+	if err := client.Do(query, variables, &response); err != nil {
+		return fmt.Errorf("failed to get issue bodies: %w", err)
+	}
+
+	for i, node := range nodes {
+		if result := response[fmt.Sprintf("n%d", i)]; result != nil {
+			node.Body = result.Body
+		}
+	}
+	return nil
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	render, ok := exportFormats[exportFormatFlag]
+	if !ok {
+		return fmt.Errorf("invalid format: %s (expected one of: %s)", exportFormatFlag, strings.Join(exportFormatNames(), ", "))
+	}
+	if exportDepthFlag < 0 || exportDepthFlag > maxTreeDepth {
+		return fmt.Errorf("invalid depth: %d (expected 0 to %d)", exportDepthFlag, maxTreeDepth)
+	}
+	if exportFieldsFlag != "" && !tabularExportFormats[exportFormatFlag] {
+		return fmt.Errorf("--fields is only supported with the csv and markdown formats")
+	}
+	fields, err := parseExportFields(exportFieldsFlag)
+	if err != nil {
+		return err
+	}
+
+	// Get default repository if not specified
+	var defaultOwner, defaultRepo string
+	if exportRepoFlag != "" {
+		parts := strings.Split(exportRepoFlag, "/")
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid repository format: %s (expected OWNER/REPO)", exportRepoFlag)
+		}
+		defaultOwner = parts[0]
+		defaultRepo = parts[1]
+	} else {
+		defaultOwner, defaultRepo, err = getDefaultRepo()
+		if err != nil {
+			return fmt.Errorf("no repository specified and could not determine from current directory: %w", err)
+		}
+	}
+
+	ref, err := parseIssueReference(args[0], defaultOwner, defaultRepo)
+	if err != nil {
+		return fmt.Errorf("invalid issue: %w", err)
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStderr(), "Getting sub-issue tree of #%d from %s/%s...\n", ref.Number, ref.Owner, ref.Repo)
+	tree, err := getIssueTree(client, ref.Owner, ref.Repo, ref.Number, exportDepthFlag)
+	if err != nil {
+		return err
+	}
+
+	// The YAML is meant to be imported again, so it must not silently leave issues out
+	if exportFormatFlag == "yaml" {
+		if missing := append(tree.missingSubIssues(), tree.missingFields()...); len(missing) > 0 {
+			return fmt.Errorf("hierarchy of #%d is too large to export as YAML: %s", ref.Number, strings.Join(missing, "; "))
+		}
+		if err := fetchBodies(client, tree); err != nil {
+			return err
+		}
+	}
+
+	output, err := render(tree, exportOptions{Owner: ref.Owner, Repo: ref.Repo, Fields: fields})
+	if err != nil {
+		return err
+	}
+
+	if exportOutputFlag == "" {
+		fmt.Fprint(cmd.OutOrStdout(), output)
+		return nil
+	}
+	if err := os.WriteFile(exportOutputFlag, []byte(output), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", exportOutputFlag, err)
+	}
+	fmt.Fprintf(cmd.OutOrStderr(), "✓ Exported #%d to %s\n", ref.Number, exportOutputFlag)
+	return nil
+}
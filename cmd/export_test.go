@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+// testExportTree builds #1 with children #2 and #3 (closed as not planned) in owner/repo
+func testExportTree() *IssueNode {
+	return &IssueNode{Number: 1, Title: `Epic "one"`, State: "open", Repository: "owner/repo",
+		URL: "https://github.com/owner/repo/issues/1", Children: []*IssueNode{
+			{Number: 2, Title: "Backend", State: "closed", StateReason: "completed", Repository: "owner/repo",
+				URL: "https://github.com/owner/repo/issues/2"},
+			{Number: 3, Title: "Dropped", State: "closed", StateReason: "not_planned", Repository: "owner/repo",
+				URL: "https://github.com/owner/repo/issues/3"},
+		}}
+}
+
+var testExportOptions = exportOptions{Owner: "owner", Repo: "repo"}
+
+func TestNewDiagram(t *testing.T) {
+	d := newDiagram(testTree(), testExportOptions)
+
+	assert.Len(t, d.Nodes, 5)
+	assert.Equal(t, "i1", d.Nodes[0].ID)
+	assert.Equal(t, "other/lib#5", d.Nodes[3].Label)
+	assert.Equal(t, [][2]string{{"i1", "i2"}, {"i1", "i5"}, {"i2", "i3"}, {"i2", "i4"}}, d.Edges)
+	assert.True(t, d.Clustered())
+	assert.Equal(t, []string{"owner/repo", "other/lib"}, d.Groups)
+	assert.Len(t, d.ByRepo["other/lib"], 1)
+}
+
+func TestExportMermaid(t *testing.T) {
+	output, err := exportMermaid(testExportTree(), testExportOptions)
+	assert.NoError(t, err)
+
+	expected := `flowchart TD
+    i1["#1 Epic #quot;one#quot;"]
+    i2["#2 Backend"]
+    i3["#3 Dropped"]
+    i1 --> i2
+    i1 --> i3
+    click i1 "https://github.com/owner/repo/issues/1" _blank
+    click i2 "https://github.com/owner/repo/issues/2" _blank
+    click i3 "https://github.com/owner/repo/issues/3" _blank
+    classDef open fill:#dafbe1,stroke:#1a7f37
+    class i1 open
+    classDef closed fill:#fbefff,stroke:#8250df
+    class i2 closed
+    classDef notPlanned fill:#f6f8fa,stroke:#59636e
+    class i3 notPlanned
+`
+	assert.Equal(t, expected, output)
+}
+
+func TestExportMermaidClusters(t *testing.T) {
+	output, err := exportMermaid(testTree(), testExportOptions)
+	assert.NoError(t, err)
+	assert.Contains(t, output, "    subgraph repo1[\"owner/repo\"]\n        i1[\"#1\"]\n")
+	assert.Contains(t, output, "    subgraph repo2[\"other/lib\"]\n        i4[\"other/lib#5\"]\n    end\n")
+}
+
+func TestExportDOT(t *testing.T) {
+	output, err := exportDOT(testExportTree(), testExportOptions)
+	assert.NoError(t, err)
+
+	assert.Contains(t, output, "digraph issues {\n")
+	assert.Contains(t, output, `    i1 [label="#1 Epic \"one\"", URL="https://github.com/owner/repo/issues/1", target="_blank", fillcolor="#dafbe1", color="#1a7f37"];`)
+	assert.Contains(t, output, "    i1 -> i3;\n")
+	assert.NotContains(t, output, "subgraph")
+
+	output, err = exportDOT(testTree(), testExportOptions)
+	assert.NoError(t, err)
+	assert.Contains(t, output, "    subgraph cluster_2 {\n        label=\"other/lib\";\n")
+}
+
+func TestExportPlantUML(t *testing.T) {
+	output, err := exportPlantUML(testExportTree(), testExportOptions)
+	assert.NoError(t, err)
+
+	assert.Contains(t, output, "@startuml\n")
+	assert.Contains(t, output, "rectangle \"#1 Epic 'one'\" as i1 [[https://github.com/owner/repo/issues/1]] #back:dafbe1;line:1a7f37\n")
+	assert.Contains(t, output, "i1 --> i2\n")
+	assert.Contains(t, output, "@enduml\n")
+
+	output, err = exportPlantUML(testTree(), testExportOptions)
+	assert.NoError(t, err)
+	assert.Contains(t, output, "package \"other/lib\" {\n")
+}
+
+func TestExportFormatNames(t *testing.T) {
+	assert.Equal(t, []string{"csv", "dot", "markdown", "mermaid", "plantuml", "yaml"}, exportFormatNames())
+}
+
+func TestExportRows(t *testing.T) {
+	rows := exportRows(testTree(), testExportOptions)
+
+	var paths []string
+	var depths []int
+	for _, row := range rows {
+		paths = append(paths, row.value("path"))
+		depths = append(depths, row.Depth)
+	}
+	assert.Equal(t, []string{"", "#1", "#1 > #2", "#1 > #2", "#1"}, paths)
+	assert.Equal(t, []int{0, 1, 2, 2, 1}, depths)
+}
+
+func TestParseExportFields(t *testing.T) {
+	fields, err := parseExportFields("")
+	assert.NoError(t, err)
+	assert.Equal(t, exportDefaultFields, fields)
+
+	fields, err = parseExportFields("number, title,type")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"number", "title", "type"}, fields)
+
+	_, err = parseExportFields("number,body")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "invalid field: body")
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	tree := testExportTree()
+	tree.Assignees = []string{"alice", "bob"}
+	tree.Milestone = &TreeMilestone{Title: "v1"}
+
+	opts := testExportOptions
+	opts.Fields = []string{"path", "depth", "number", "title", "assignees", "milestone"}
+	output, err := exportCSV(tree, opts)
+	assert.NoError(t, err)
+
+	expected := "path,depth,number,title,assignees,milestone\n" +
+		",0,1,\"Epic \"\"one\"\"\",\"alice, bob\",v1\n" +
+		"#1,1,2,Backend,,\n" +
+		"#1,1,3,Dropped,,\n"
+	assert.Equal(t, expected, output)
+}
+
+func TestExportMarkdown(t *testing.T) {
+	tree := testExportTree()
+	tree.Title = "Epic | one"
+
+	opts := testExportOptions
+	opts.Fields = []string{"path", "number", "title", "state"}
+	output, err := exportMarkdown(tree, opts)
+	assert.NoError(t, err)
+
+	expected := "| path | number | title | state |\n" +
+		"| --- | --- | --- | --- |\n" +
+		"|  | 1 | Epic \\| one | open |\n" +
+		"| #1 | 2 | Backend | closed |\n" +
+		"| #1 | 3 | Dropped | closed |\n"
+	assert.Equal(t, expected, output)
+}
+
+func TestExportYAMLRoundTrip(t *testing.T) {
+	tree := testExportTree()
+	tree.Body = "Line one\nLine two"
+	tree.Labels = []string{"epic"}
+	tree.Milestone = &TreeMilestone{Title: "v1", DueOn: "2025-01-31T00:00:00Z"}
+	tree.Type = "Feature"
+
+	output, err := exportYAML(tree, testExportOptions)
+	assert.NoError(t, err)
+	assert.Contains(t, output, "  stateReason: not_planned\n")
+
+	// The export is a valid --from-file task list
+	items, err := parseBatchYAML(output)
+	assert.NoError(t, err)
+	if assert.Len(t, items, 1) {
+		assert.Equal(t, `Epic "one"`, items[0].Title)
+		assert.Equal(t, "Line one\nLine two", items[0].Body)
+		assert.Equal(t, stringList{"epic"}, items[0].Labels)
+		assert.Equal(t, "v1", items[0].Milestone)
+		assert.Equal(t, "Feature", items[0].Type)
+		assert.Len(t, items[0].Children, 2)
+	}
+
+	// and keeps everything else
+	var exported []exportItem
+	assert.NoError(t, yaml.Unmarshal([]byte(output), &exported))
+	assert.Equal(t, []exportItem{newExportItem(tree)}, exported)
+}
@@ -0,0 +1,317 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// FieldSpec is a parsed --field value: [PROJECT:]FIELD=VALUE
+type FieldSpec struct {
+	Project string
+	Field   string
+	Value   string
+}
+
+// ProjectFieldOption is an option of a single-select field
+type ProjectFieldOption struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// ProjectIteration is an iteration of an iteration field
+type ProjectIteration struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	StartDate string `json:"startDate"`
+	Duration  int    `json:"duration"`
+}
+
+// ProjectField is a custom field of a ProjectV2 board
+type ProjectField struct {
+	ID         string               `json:"id"`
+	Name       string               `json:"name"`
+	DataType   string               `json:"dataType"`
+	Options    []ProjectFieldOption `json:"options"`
+	Iterations []ProjectIteration   `json:"iterations"`
+}
+
+// ProjectFields holds a board and its fields
+type ProjectFields struct {
+	ID     string
+	Title  string
+	Number int
+	Fields []ProjectField
+}
+
+// FieldUpdate is a resolved field value to set on a project item
+type FieldUpdate struct {
+	ProjectID    string
+	ProjectTitle string
+	FieldID      string
+	FieldName    string
+	Value        map[string]interface{}
+}
+
+// parseFieldFlag parses a --field value in the form [PROJECT:]FIELD=VALUE
+func parseFieldFlag(s string) (FieldSpec, error) {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok {
+		return FieldSpec{}, fmt.Errorf("invalid field %q (expected [PROJECT:]FIELD=VALUE)", s)
+	}
+
+	spec := FieldSpec{Field: strings.TrimSpace(key), Value: strings.TrimSpace(value)}
+	if project, field, ok := strings.Cut(key, ":"); ok {
+		spec.Project = strings.TrimSpace(project)
+		spec.Field = strings.TrimSpace(field)
+	}
+
+	if spec.Field == "" {
+		return FieldSpec{}, fmt.Errorf("invalid field %q: missing field name", s)
+	}
+	return spec, nil
+}
+
+// getProjectFields gets the custom fields of a ProjectV2 board
+func getProjectFields(client *api.GraphQLClient, projectID string) (*ProjectFields, error) {
+	query := `
+		query($id: ID!) {
+			node(id: $id) {
+				... on ProjectV2 {
+					id
+					title
+					number
+					fields(first: 100) {
+						nodes {
+							... on ProjectV2FieldCommon {
+								id
+								name
+								dataType
+							}
+							... on ProjectV2SingleSelectField {
+								options {
+									id
+									name
+								}
+							}
+							... on ProjectV2IterationField {
+								configuration {
+									iterations {
+										id
+										title
+										startDate
+										duration
+									}
+									completedIterations {
+										id
+										title
+										startDate
+										duration
+									}
+								}
+							}
+						}
+					}
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"id": projectID,
+	}
+
+	var response struct {
+		Node struct {
+			ID     string `json:"id"`
+			Title  string `json:"title"`
+			Number int    `json:"number"`
+			Fields struct {
+				Nodes []struct {
+					ID            string               `json:"id"`
+					Name          string               `json:"name"`
+					DataType      string               `json:"dataType"`
+					Options       []ProjectFieldOption `json:"options"`
+					Configuration struct {
+						Iterations          []ProjectIteration `json:"iterations"`
+						CompletedIterations []ProjectIteration `json:"completedIterations"`
+					} `json:"configuration"`
+				} `json:"nodes"`
+			} `json:"fields"`
+		} `json:"node"`
+	}
+
+	err := client.Do(query, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project fields: %w", err)
+	}
+
+	project := &ProjectFields{
+		ID:     response.Node.ID,
+		Title:  response.Node.Title,
+		Number: response.Node.Number,
+	}
+	for _, node := range response.Node.Fields.Nodes {
+		if node.ID == "" {
+			continue
+		}
+		project.Fields = append(project.Fields, ProjectField{
+			ID:         node.ID,
+			Name:       node.Name,
+			DataType:   node.DataType,
+			Options:    node.Options,
+			Iterations: append(node.Configuration.Iterations, node.Configuration.CompletedIterations...),
+		})
+	}
+
+	return project, nil
+}
+
+// resolveFieldValue converts a textual value into a ProjectV2FieldValue for the field's type.
+// Iteration fields accept an iteration title or "@current".
+func resolveFieldValue(field ProjectField, value string, today time.Time) (map[string]interface{}, error) {
+	switch field.DataType {
+	case "TEXT":
+		return map[string]interface{}{"text": value}, nil
+	case "NUMBER":
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("field '%s' expects a number, got %q", field.Name, value)
+		}
+		return map[string]interface{}{"number": number}, nil
+	case "DATE":
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return nil, fmt.Errorf("field '%s' expects a date in YYYY-MM-DD format, got %q", field.Name, value)
+		}
+		return map[string]interface{}{"date": value}, nil
+	case "SINGLE_SELECT":
+		var names []string
+		for _, option := range field.Options {
+			if strings.EqualFold(option.Name, value) {
+				return map[string]interface{}{"singleSelectOptionId": option.ID}, nil
+			}
+			names = append(names, option.Name)
+		}
+		return nil, fmt.Errorf("option %q not found for field '%s' (available: %s)", value, field.Name, strings.Join(names, ", "))
+	case "ITERATION":
+		for _, iteration := range field.Iterations {
+			if strings.EqualFold(value, "@current") {
+				start, err := time.Parse("2006-01-02", iteration.StartDate)
+				if err != nil {
+					continue
+				}
+				end := start.AddDate(0, 0, iteration.Duration)
+				if !today.Before(start) && today.Before(end) {
+					return map[string]interface{}{"iterationId": iteration.ID}, nil
+				}
+				continue
+			}
+			if strings.EqualFold(iteration.Title, value) {
+				return map[string]interface{}{"iterationId": iteration.ID}, nil
+			}
+		}
+		return nil, fmt.Errorf("iteration %q not found for field '%s'", value, field.Name)
+	default:
+		return nil, fmt.Errorf("field '%s' has unsupported type %s", field.Name, field.DataType)
+	}
+}
+
+// matchesProject reports whether a --field project qualifier refers to the board.
+// An empty qualifier matches every board.
+func matchesProject(qualifier string, project *ProjectFields, name string) bool {
+	if qualifier == "" {
+		return true
+	}
+	return strings.EqualFold(qualifier, project.Title) ||
+		strings.EqualFold(qualifier, name) ||
+		qualifier == fmt.Sprint(project.Number)
+}
+
+// resolveFieldUpdates resolves every --field value against the boards the issue is added to
+func resolveFieldUpdates(specs []FieldSpec, projects []*ProjectFields, names []string, today time.Time) ([]FieldUpdate, error) {
+	var updates []FieldUpdate
+
+	for _, spec := range specs {
+		matched := false
+		for i, project := range projects {
+			if !matchesProject(spec.Project, project, names[i]) {
+				continue
+			}
+
+			var field *ProjectField
+			for j := range project.Fields {
+				if strings.EqualFold(project.Fields[j].Name, spec.Field) {
+					field = &project.Fields[j]
+					break
+				}
+			}
+			if field == nil {
+				if spec.Project == "" {
+					// Unqualified fields only need to exist on some of the boards
+					continue
+				}
+				return nil, fmt.Errorf("field '%s' not found in project '%s'", spec.Field, project.Title)
+			}
+
+			value, err := resolveFieldValue(*field, spec.Value, today)
+			if err != nil {
+				return nil, fmt.Errorf("project '%s': %w", project.Title, err)
+			}
+
+			matched = true
+			updates = append(updates, FieldUpdate{
+				ProjectID:    project.ID,
+				ProjectTitle: project.Title,
+				FieldID:      field.ID,
+				FieldName:    field.Name,
+				Value:        value,
+			})
+		}
+
+		if !matched {
+			if spec.Project != "" {
+				return nil, fmt.Errorf("project '%s' is not one of the projects the issue is added to", spec.Project)
+			}
+			return nil, fmt.Errorf("field '%s' not found in any project", spec.Field)
+		}
+	}
+
+	return updates, nil
+}
+
+// updateProjectV2ItemField sets a custom field value on a project item
+func updateProjectV2ItemField(client *api.GraphQLClient, update FieldUpdate, itemID string) error {
+	mutation := `
+		mutation UpdateProjectV2ItemFieldValue($projectId: ID!, $itemId: ID!, $fieldId: ID!, $value: ProjectV2FieldValue!) {
+			updateProjectV2ItemFieldValue(input: {projectId: $projectId, itemId: $itemId, fieldId: $fieldId, value: $value}) {
+				projectV2Item {
+					id
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"projectId": update.ProjectID,
+		"itemId":    itemID,
+		"fieldId":   update.FieldID,
+		"value":     update.Value,
+	}
+
+	var response struct {
+		UpdateProjectV2ItemFieldValue struct {
+			ProjectV2Item struct {
+				ID string `json:"id"`
+			} `json:"projectV2Item"`
+		} `json:"updateProjectV2ItemFieldValue"`
+	}
+
+	description := fmt.Sprintf("Set '%s' in project '%s'", update.FieldName, update.ProjectTitle)
+	err := doMutation(client, "updateProjectV2ItemFieldValue", description, mutation, variables, &response)
+	if err != nil {
+		return fmt.Errorf("failed to set field '%s': %w", update.FieldName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,209 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFieldFlag(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected FieldSpec
+		wantErr  bool
+	}{
+		{
+			name:     "project qualified",
+			input:    "Roadmap:Status=In Progress",
+			expected: FieldSpec{Project: "Roadmap", Field: "Status", Value: "In Progress"},
+		},
+		{
+			name:     "unqualified",
+			input:    "Estimate=3",
+			expected: FieldSpec{Field: "Estimate", Value: "3"},
+		},
+		{
+			name:     "value containing separators",
+			input:    "Notes=a=b:c, d",
+			expected: FieldSpec{Field: "Notes", Value: "a=b:c, d"},
+		},
+		{
+			name:    "missing value separator",
+			input:   "Status",
+			wantErr: true,
+		},
+		{
+			name:    "missing field name",
+			input:   "Roadmap:=Done",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := parseFieldFlag(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, spec)
+		})
+	}
+}
+
+func TestResolveFieldValue(t *testing.T) {
+	today := time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)
+	iterationField := ProjectField{
+		Name:     "Sprint",
+		DataType: "ITERATION",
+		Iterations: []ProjectIteration{
+			{ID: "IT_1", Title: "Sprint 1", StartDate: "2024-02-26", Duration: 14},
+			{ID: "IT_2", Title: "Sprint 2", StartDate: "2024-03-11", Duration: 14},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		field    ProjectField
+		value    string
+		expected map[string]interface{}
+		wantErr  bool
+	}{
+		{
+			name:     "text",
+			field:    ProjectField{Name: "Notes", DataType: "TEXT"},
+			value:    "hello",
+			expected: map[string]interface{}{"text": "hello"},
+		},
+		{
+			name:     "number",
+			field:    ProjectField{Name: "Estimate", DataType: "NUMBER"},
+			value:    "2.5",
+			expected: map[string]interface{}{"number": 2.5},
+		},
+		{
+			name:    "invalid number",
+			field:   ProjectField{Name: "Estimate", DataType: "NUMBER"},
+			value:   "big",
+			wantErr: true,
+		},
+		{
+			name:     "date",
+			field:    ProjectField{Name: "Due", DataType: "DATE"},
+			value:    "2024-04-01",
+			expected: map[string]interface{}{"date": "2024-04-01"},
+		},
+		{
+			name:    "invalid date",
+			field:   ProjectField{Name: "Due", DataType: "DATE"},
+			value:   "01/04/2024",
+			wantErr: true,
+		},
+		{
+			name: "single select case-insensitive",
+			field: ProjectField{Name: "Status", DataType: "SINGLE_SELECT", Options: []ProjectFieldOption{
+				{ID: "OPT_1", Name: "Todo"}, {ID: "OPT_2", Name: "In Progress"},
+			}},
+			value:    "in progress",
+			expected: map[string]interface{}{"singleSelectOptionId": "OPT_2"},
+		},
+		{
+			name:    "unknown option",
+			field:   ProjectField{Name: "Status", DataType: "SINGLE_SELECT"},
+			value:   "Blocked",
+			wantErr: true,
+		},
+		{
+			name:     "iteration by title",
+			field:    iterationField,
+			value:    "sprint 2",
+			expected: map[string]interface{}{"iterationId": "IT_2"},
+		},
+		{
+			name:     "current iteration",
+			field:    iterationField,
+			value:    "@current",
+			expected: map[string]interface{}{"iterationId": "IT_1"},
+		},
+		{
+			name:    "unsupported type",
+			field:   ProjectField{Name: "Assignees", DataType: "ASSIGNEES"},
+			value:   "alice",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := resolveFieldValue(tt.field, tt.value, today)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestResolveFieldUpdates(t *testing.T) {
+	roadmap := &ProjectFields{ID: "PVT_1", Title: "Roadmap", Number: 1, Fields: []ProjectField{
+		{ID: "F_STATUS", Name: "Status", DataType: "SINGLE_SELECT", Options: []ProjectFieldOption{{ID: "OPT_1", Name: "Done"}}},
+		{ID: "F_EST", Name: "Estimate", DataType: "NUMBER"},
+	}}
+	sprint := &ProjectFields{ID: "PVT_2", Title: "Sprint Board", Number: 7, Fields: []ProjectField{
+		{ID: "F_EST2", Name: "Estimate", DataType: "NUMBER"},
+	}}
+	projects := []*ProjectFields{roadmap, sprint}
+	names := []string{"Roadmap", "7"}
+	today := time.Now()
+
+	t.Run("unqualified field applies to every board that has it", func(t *testing.T) {
+		updates, err := resolveFieldUpdates([]FieldSpec{{Field: "Estimate", Value: "3"}}, projects, names, today)
+		assert.NoError(t, err)
+		assert.Len(t, updates, 2)
+		assert.Equal(t, "F_EST", updates[0].FieldID)
+		assert.Equal(t, "F_EST2", updates[1].FieldID)
+	})
+
+	t.Run("qualified by project number", func(t *testing.T) {
+		updates, err := resolveFieldUpdates([]FieldSpec{{Project: "7", Field: "estimate", Value: "1"}}, projects, names, today)
+		assert.NoError(t, err)
+		assert.Len(t, updates, 1)
+		assert.Equal(t, "PVT_2", updates[0].ProjectID)
+	})
+
+	t.Run("field missing in qualified project", func(t *testing.T) {
+		_, err := resolveFieldUpdates([]FieldSpec{{Project: "Sprint Board", Field: "Status", Value: "Done"}}, projects, names, today)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "field 'Status' not found in project 'Sprint Board'")
+		}
+	})
+
+	t.Run("unknown project", func(t *testing.T) {
+		_, err := resolveFieldUpdates([]FieldSpec{{Project: "Other", Field: "Status", Value: "Done"}}, projects, names, today)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "not one of the projects")
+		}
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := resolveFieldUpdates([]FieldSpec{{Field: "Priority", Value: "P1"}}, projects, names, today)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "not found in any project")
+		}
+	})
+}
+
+func TestUpdateProjectV2ItemFieldDryRun(t *testing.T) {
+	withDryRun(t)
+
+	update := FieldUpdate{ProjectID: "PVT_1", ProjectTitle: "Roadmap", FieldID: "F_1", FieldName: "Status", Value: map[string]interface{}{"singleSelectOptionId": "OPT_1"}}
+	assert.NoError(t, updateProjectV2ItemField(nil, update, "<addProjectV2ItemById#2>"))
+	assert.Len(t, plan.Mutations, 1)
+	assert.Equal(t, "updateProjectV2ItemFieldValue", plan.Mutations[0].Name)
+	assert.Equal(t, "<addProjectV2ItemById#2>", plan.Mutations[0].Variables["itemId"])
+}
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// Attributes a sub-issue can inherit from its parent
+const (
+	inheritLabels    = "labels"
+	inheritMilestone = "milestone"
+	inheritProjects  = "projects"
+	inheritAssignees = "assignees"
+)
+
+var inheritableAttributes = []string{inheritLabels, inheritMilestone, inheritProjects, inheritAssignees}
+
+// ProjectRef identifies a ProjectV2 board
+type ProjectRef struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Number int    `json:"number"`
+}
+
+// IssueAttributes holds the attributes of an issue that sub-issues can inherit
+type IssueAttributes struct {
+	Labels    []string
+	Assignees []string
+	Milestone string
+	Projects  []ProjectRef
+}
+
+// parseInheritFlag validates the --inherit values and returns the selected attributes
+func parseInheritFlag(values []string) (map[string]bool, error) {
+	selected := make(map[string]bool)
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "" {
+			continue
+		}
+		if value == "all" {
+			for _, attr := range inheritableAttributes {
+				selected[attr] = true
+			}
+			continue
+		}
+
+		valid := false
+		for _, attr := range inheritableAttributes {
+			if value == attr {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("invalid --inherit value: %s (valid values: %s, all)", value, strings.Join(inheritableAttributes, ", "))
+		}
+		selected[value] = true
+	}
+	return selected, nil
+}
+
+// getIssueAttributes gets the labels, assignees, milestone and projects of an issue
+func getIssueAttributes(client *api.GraphQLClient, owner, repo string, number int) (*IssueAttributes, error) {
+	query := `
+		query($owner: String!, $repo: String!, $number: Int!) {
+			repository(owner: $owner, name: $repo) {
+				issue(number: $number) {
+					labels(first: 100) {
+						nodes {
+							name
+						}
+					}
+					assignees(first: 100) {
+						nodes {
+							login
+						}
+					}
+					milestone {
+						title
+					}
+					projectItems(first: 100) {
+						nodes {
+							project {
+								id
+								title
+								number
+							}
+						}
+					}
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"owner":  owner,
+		"repo":   repo,
+		"number": number,
+	}
+
+	var response struct {
+		Repository struct {
+			Issue struct {
+				Labels struct {
+					Nodes []struct {
+						Name string `json:"name"`
+					} `json:"nodes"`
+				} `json:"labels"`
+				Assignees struct {
+					Nodes []struct {
+						Login string `json:"login"`
+					} `json:"nodes"`
+				} `json:"assignees"`
+				Milestone *struct {
+					Title string `json:"title"`
+				} `json:"milestone"`
+				ProjectItems struct {
+					Nodes []struct {
+						Project ProjectRef `json:"project"`
+					} `json:"nodes"`
+				} `json:"projectItems"`
+			} `json:"issue"`
+		} `json:"repository"`
+	}
+
+	err := client.Do(query, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attributes of issue #%d: %w", number, err)
+	}
+
+	issue := response.Repository.Issue
+	attrs := &IssueAttributes{}
+	for _, label := range issue.Labels.Nodes {
+		attrs.Labels = append(attrs.Labels, label.Name)
+	}
+	for _, assignee := range issue.Assignees.Nodes {
+		attrs.Assignees = append(attrs.Assignees, assignee.Login)
+	}
+	if issue.Milestone != nil {
+		attrs.Milestone = issue.Milestone.Title
+	}
+	for _, item := range issue.ProjectItems.Nodes {
+		if item.Project.ID != "" {
+			attrs.Projects = append(attrs.Projects, item.Project)
+		}
+	}
+
+	return attrs, nil
+}
+
+// mergeNames appends inherited names that are not already present (case-insensitive)
+func mergeNames(explicit, inherited []string) []string {
+	seen := make(map[string]bool)
+	merged := []string{}
+	for _, name := range append(append([]string{}, explicit...), inherited...) {
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		merged = append(merged, name)
+	}
+	return merged
+}
+
+// applyInheritance merges the selected parent attributes into the explicit create options.
+// Explicit values always win: labels and assignees are combined, and the parent milestone
+// is only used when no --milestone was given. Inherited projects are returned separately
+// because they are already resolved to node IDs.
+func applyInheritance(selected map[string]bool, parent *IssueAttributes, labels, assignees []string, milestone string) ([]string, []string, string, []ProjectRef) {
+	var projects []ProjectRef
+
+	if selected[inheritLabels] {
+		labels = mergeNames(labels, parent.Labels)
+	}
+	if selected[inheritAssignees] {
+		assignees = mergeNames(assignees, parent.Assignees)
+	}
+	if selected[inheritMilestone] && milestone == "" {
+		milestone = parent.Milestone
+	}
+	if selected[inheritProjects] {
+		projects = parent.Projects
+	}
+
+	return labels, assignees, milestone, projects
+}
+
+// containsID reports whether id is in ids
+func containsID(ids []string, id string) bool {
+	for _, existing := range ids {
+		if existing == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInheritFlag(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []string
+		expected map[string]bool
+		wantErr  bool
+	}{
+		{
+			name:     "not set",
+			values:   nil,
+			expected: map[string]bool{},
+		},
+		{
+			name:   "bare flag default",
+			values: inheritableAttributes,
+			expected: map[string]bool{
+				"labels": true, "milestone": true, "projects": true, "assignees": true,
+			},
+		},
+		{
+			name:     "subset with spaces and case",
+			values:   []string{" Milestone", "projects "},
+			expected: map[string]bool{"milestone": true, "projects": true},
+		},
+		{
+			name:   "all keyword",
+			values: []string{"all"},
+			expected: map[string]bool{
+				"labels": true, "milestone": true, "projects": true, "assignees": true,
+			},
+		},
+		{
+			name:    "invalid value",
+			values:  []string{"labels", "body"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := parseInheritFlag(tt.values)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, selected)
+		})
+	}
+}
+
+func TestMergeNames(t *testing.T) {
+	merged := mergeNames([]string{"bug", "Backend"}, []string{"backend", "epic", "bug", ""})
+	assert.Equal(t, []string{"bug", "Backend", "epic"}, merged)
+
+	assert.Equal(t, []string{}, mergeNames(nil, nil))
+}
+
+func TestApplyInheritance(t *testing.T) {
+	parent := &IssueAttributes{
+		Labels:    []string{"epic", "backend"},
+		Assignees: []string{"alice"},
+		Milestone: "v1.0",
+		Projects:  []ProjectRef{{ID: "PVT_1", Title: "Roadmap", Number: 1}},
+	}
+
+	t.Run("all attributes", func(t *testing.T) {
+		selected, _ := parseInheritFlag([]string{"all"})
+		labels, assignees, milestone, projects := applyInheritance(selected, parent, []string{"bug"}, nil, "")
+		assert.Equal(t, []string{"bug", "epic", "backend"}, labels)
+		assert.Equal(t, []string{"alice"}, assignees)
+		assert.Equal(t, "v1.0", milestone)
+		assert.Equal(t, parent.Projects, projects)
+	})
+
+	t.Run("explicit milestone wins", func(t *testing.T) {
+		selected, _ := parseInheritFlag([]string{"milestone"})
+		labels, assignees, milestone, projects := applyInheritance(selected, parent, []string{"bug"}, []string{"bob"}, "v2.0")
+		assert.Equal(t, []string{"bug"}, labels)
+		assert.Equal(t, []string{"bob"}, assignees)
+		assert.Equal(t, "v2.0", milestone)
+		assert.Nil(t, projects)
+	})
+}
+
+func TestContainsID(t *testing.T) {
+	assert.True(t, containsID([]string{"a", "b"}, "b"))
+	assert.False(t, containsID([]string{"a", "b"}, "c"))
+	assert.False(t, containsID(nil, "a"))
+}
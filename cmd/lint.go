@@ -0,0 +1,632 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Built-in lint rules
+const (
+	ruleClosedParent = "closed-parent-open-children"
+	ruleMilestone    = "milestone-after-parent"
+	ruleLeafAssignee = "leaf-assignee"
+	ruleMaxDepth     = "max-depth"
+	ruleEpicLabel    = "epic-label"
+)
+
+// Finding severities
+const (
+	severityError   = "error"
+	severityWarning = "warning"
+)
+
+// lintConfigFiles are looked up in the local checkout when --config is not given
+var lintConfigFiles = []string{".github/sub-issue-lint.yml", ".github/sub-issue-lint.yaml"}
+
+var (
+	lintRepoFlag   string
+	lintConfigFlag string
+	lintJSONFlag   bool
+	lintFailOnFlag string
+	lintFixFlag    bool
+)
+
+var lintCmd = &cobra.Command{
+	Use:   "lint [<issue>]",
+	Short: "Check a sub-issue hierarchy against consistency rules",
+	Long: `Check an issue and its sub-issues against hierarchy rules. Without an issue,
+every top-level issue, open or closed, with sub-issues in the repository is checked.
+
+Built-in rules:
+  closed-parent-open-children   a closed issue must not have open sub-issues
+  milestone-after-parent        a sub-issue's milestone must not be due after its parent's
+  leaf-assignee                 open issues without sub-issues must have an assignee
+  max-depth                     the hierarchy must not be deeper than 3 levels
+  epic-label                    top-level issues with sub-issues must have the 'epic' label
+
+Rules can be configured, disabled or extended with custom rules in
+.github/sub-issue-lint.yml (or the file given with --config):
+
+  rules:
+    max-depth:
+      max: 4
+    leaf-assignee:
+      severity: warning
+    epic-label:
+      label: initiative
+  custom:
+    - name: bugs-need-priority
+      message: Bugs need a priority label
+      when:
+        labels: [bug]
+        state: open
+      require:
+        labels: [priority]
+
+With --fix, findings that can be resolved automatically are fixed: a closed
+parent with open sub-issues is reopened, a sub-issue due after its parent gets
+the parent's milestone and a missing epic label is added. Use --dry-run to
+preview the fixes.
+
+Exit status is 0 when no findings reach --fail-on, 1 when they do and 2 when the
+check could not run. With --fix, only the findings left after fixing count; with
+--fix --dry-run, only the findings that would be left count, and the planned
+changes are printed to stderr before the report.
+
+Examples:
+  # Check epic #123
+  gh sub-issue lint 123
+
+  # Check every hierarchy in a repository in CI
+  gh sub-issue lint --repo owner/repo --json
+
+  # Preview the automatic fixes
+  gh sub-issue lint 123 --fix --dry-run`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runLint,
+}
+
+func init() {
+	rootCmd.AddCommand(lintCmd)
+	lintCmd.Flags().StringVarP(&lintRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+	lintCmd.Flags().StringVar(&lintConfigFlag, "config", "", "Path to a lint configuration file")
+	lintCmd.Flags().BoolVar(&lintJSONFlag, "json", false, "Output findings as JSON")
+	lintCmd.Flags().StringVar(&lintFailOnFlag, "fail-on", severityError, "Exit with status 1 on findings of this severity or worse: {error|warning}")
+	lintCmd.Flags().BoolVar(&lintFixFlag, "fix", false, "Fix findings that can be resolved automatically")
+}
+
+// RuleConfig configures a built-in rule
+type RuleConfig struct {
+	Enabled  *bool  `yaml:"enabled"`
+	Severity string `yaml:"severity"`
+	Max      int    `yaml:"max"`
+	Label    string `yaml:"label"`
+}
+
+// isEnabled reports whether the rule is enabled; rules are enabled unless turned off
+func (r RuleConfig) isEnabled() bool {
+	return r.Enabled == nil || *r.Enabled
+}
+
+// lintSelector selects the issues a custom rule applies to
+type lintSelector struct {
+	Labels stringList `yaml:"labels"`
+	State  string     `yaml:"state"`
+	Leaf   *bool      `yaml:"leaf"`
+	Root   *bool      `yaml:"root"`
+}
+
+// lintRequirements is what a custom rule requires of the selected issues
+type lintRequirements struct {
+	Labels    stringList `yaml:"labels"`
+	Assignee  bool       `yaml:"assignee"`
+	Milestone bool       `yaml:"milestone"`
+}
+
+// CustomRule is a rule defined in the lint configuration
+type CustomRule struct {
+	Name     string           `yaml:"name"`
+	Message  string           `yaml:"message"`
+	Severity string           `yaml:"severity"`
+	When     lintSelector     `yaml:"when"`
+	Require  lintRequirements `yaml:"require"`
+}
+
+// LintConfig is the lint configuration file
+type LintConfig struct {
+	Rules  map[string]RuleConfig `yaml:"rules"`
+	Custom []CustomRule          `yaml:"custom"`
+}
+
+// LintFinding is a rule violation
+type LintFinding struct {
+	Rule       string `json:"rule"`
+	Severity   string `json:"severity"`
+	Number     int    `json:"number"`
+	Title      string `json:"title"`
+	Repository string `json:"repository"`
+	URL        string `json:"url"`
+	Message    string `json:"message"`
+
+	// node and parent are the issues involved, for fixes
+	node   *IssueNode
+	parent *IssueNode
+}
+
+// LintReport is the result of a lint run
+type LintReport struct {
+	Findings []LintFinding `json:"findings"`
+	Checked  int           `json:"checked"`
+	Errors   int           `json:"errors"`
+	Warnings int           `json:"warnings"`
+	Fixes    []LintFix     `json:"fixes,omitempty"`
+}
+
+// lintTarget is an issue being checked, with its place in the tree
+type lintTarget struct {
+	Node   *IssueNode
+	Parent *IssueNode
+	Level  int
+}
+
+// isLeaf reports whether the issue has no sub-issues
+func (t lintTarget) isLeaf() bool {
+	return len(t.Node.Children) == 0 && t.Node.subIssueCount == 0
+}
+
+// lintRule is a built-in rule; Check returns a message when the issue violates it
+type lintRule struct {
+	Name  string
+	Check func(t lintTarget, rule RuleConfig) string
+}
+
+var builtinLintRules = []lintRule{
+	{
+		Name: ruleClosedParent,
+		Check: func(t lintTarget, rule RuleConfig) string {
+			if t.Node.State != "closed" {
+				return ""
+			}
+			open := 0
+			for _, child := range t.Node.Children {
+				if child.State == "open" {
+					open++
+				}
+			}
+			if open == 0 {
+				return ""
+			}
+			return fmt.Sprintf("closed, but %d sub-issue(s) are still open", open)
+		},
+	},
+	{
+		Name: ruleMilestone,
+		Check: func(t lintTarget, rule RuleConfig) string {
+			if t.Parent == nil || t.Node.Milestone == nil || t.Parent.Milestone == nil {
+				return ""
+			}
+			due, err := time.Parse(time.RFC3339, t.Node.Milestone.DueOn)
+			if err != nil {
+				return ""
+			}
+			parentDue, err := time.Parse(time.RFC3339, t.Parent.Milestone.DueOn)
+			if err != nil || !due.After(parentDue) {
+				return ""
+			}
+			return fmt.Sprintf("milestone '%s' is due after the parent's milestone '%s'",
+				t.Node.Milestone.Title, t.Parent.Milestone.Title)
+		},
+	},
+	{
+		Name: ruleLeafAssignee,
+		Check: func(t lintTarget, rule RuleConfig) string {
+			if !t.isLeaf() || t.Node.State != "open" || len(t.Node.Assignees) > 0 {
+				return ""
+			}
+			return "open issue without sub-issues has no assignee"
+		},
+	},
+	{
+		Name: ruleMaxDepth,
+		Check: func(t lintTarget, rule RuleConfig) string {
+			if t.Level <= rule.Max {
+				return ""
+			}
+			return fmt.Sprintf("hierarchy is %d levels deep (max %d)", t.Level, rule.Max)
+		},
+	},
+	{
+		Name: ruleEpicLabel,
+		Check: func(t lintTarget, rule RuleConfig) string {
+			if t.Parent != nil || t.isLeaf() || hasLabel(t.Node.Labels, rule.Label) {
+				return ""
+			}
+			return fmt.Sprintf("top-level issue with sub-issues is missing the '%s' label", rule.Label)
+		},
+	},
+}
+
+// defaultLintConfig returns the configuration of the built-in rules
+func defaultLintConfig() *LintConfig {
+	return &LintConfig{
+		Rules: map[string]RuleConfig{
+			ruleClosedParent: {Severity: severityError},
+			ruleMilestone:    {Severity: severityError},
+			ruleLeafAssignee: {Severity: severityError},
+			ruleMaxDepth:     {Severity: severityError, Max: 3},
+			ruleEpicLabel:    {Severity: severityError, Label: "epic"},
+		},
+	}
+}
+
+// parseLintConfig merges a YAML configuration into the defaults
+func parseLintConfig(content string) (*LintConfig, error) {
+	var file LintConfig
+	if err := yaml.Unmarshal([]byte(content), &file); err != nil {
+		return nil, fmt.Errorf("invalid lint configuration: %w", err)
+	}
+
+	config := defaultLintConfig()
+	for name, override := range file.Rules {
+		rule, ok := config.Rules[name]
+		if !ok {
+			return nil, fmt.Errorf("invalid lint configuration: unknown rule '%s'", name)
+		}
+		if override.Enabled != nil {
+			rule.Enabled = override.Enabled
+		}
+		if override.Severity != "" {
+			rule.Severity = override.Severity
+		}
+		if override.Max > 0 {
+			rule.Max = override.Max
+		}
+		if override.Label != "" {
+			rule.Label = override.Label
+		}
+		if !isSeverity(rule.Severity) {
+			return nil, fmt.Errorf("invalid lint configuration: rule '%s' has invalid severity '%s'", name, rule.Severity)
+		}
+		config.Rules[name] = rule
+	}
+
+	for i, custom := range file.Custom {
+		if custom.Name == "" {
+			return nil, fmt.Errorf("invalid lint configuration: custom rule %d has no name", i+1)
+		}
+		if custom.Severity == "" {
+			file.Custom[i].Severity = severityError
+		} else if !isSeverity(custom.Severity) {
+			return nil, fmt.Errorf("invalid lint configuration: rule '%s' has invalid severity '%s'", custom.Name, custom.Severity)
+		}
+	}
+	config.Custom = file.Custom
+
+	return config, nil
+}
+
+// isSeverity reports whether s is a valid severity
+func isSeverity(s string) bool {
+	return s == severityError || s == severityWarning
+}
+
+// loadLintConfig reads the configuration from path, or from the local checkout when path is empty
+func loadLintConfig(path string) (*LintConfig, error) {
+	if path == "" {
+		for _, file := range lintConfigFiles {
+			if local := localRepoFile(file); local != "" {
+				path = local
+				break
+			}
+		}
+		if path == "" {
+			return defaultLintConfig(), nil
+		}
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lint configuration: %w", err)
+	}
+	return parseLintConfig(string(content))
+}
+
+// localRepoFile returns the path of a file in the local checkout, or "" when it does not exist
+func localRepoFile(rel string) string {
+	output, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return ""
+	}
+
+	path := filepath.Join(strings.TrimSpace(string(output)), filepath.FromSlash(rel))
+	if _, err := os.Stat(path); err != nil {
+		return ""
+	}
+	return path
+}
+
+// hasLabel reports whether labels contains label, ignoring case
+func hasLabel(labels []string, label string) bool {
+	for _, l := range labels {
+		if strings.EqualFold(l, label) {
+			return true
+		}
+	}
+	return false
+}
+
+// matches reports whether a custom rule applies to the issue
+func (s lintSelector) matches(t lintTarget) bool {
+	for _, label := range s.Labels {
+		if !hasLabel(t.Node.Labels, label) {
+			return false
+		}
+	}
+	if s.State != "" && !strings.EqualFold(s.State, t.Node.State) {
+		return false
+	}
+	if s.Leaf != nil && *s.Leaf != t.isLeaf() {
+		return false
+	}
+	if s.Root != nil && *s.Root != (t.Parent == nil) {
+		return false
+	}
+	return true
+}
+
+// check returns a message when the issue does not meet a custom rule
+func (r CustomRule) check(t lintTarget) string {
+	if !r.When.matches(t) {
+		return ""
+	}
+
+	var problems []string
+	for _, label := range r.Require.Labels {
+		if !hasLabel(t.Node.Labels, label) {
+			problems = append(problems, fmt.Sprintf("missing label '%s'", label))
+		}
+	}
+	if r.Require.Assignee && len(t.Node.Assignees) == 0 {
+		problems = append(problems, "no assignee")
+	}
+	if r.Require.Milestone && t.Node.Milestone == nil {
+		problems = append(problems, "no milestone")
+	}
+
+	if len(problems) == 0 {
+		return ""
+	}
+	if r.Message != "" {
+		return r.Message
+	}
+	return strings.Join(problems, ", ")
+}
+
+// lintTree checks every issue of the tree and adds the findings to the report. parent is
+// the parent of the tree's root, nil for a top-level issue, and level is the root's depth
+// in the whole hierarchy, 1 for a top-level issue.
+func lintTree(tree, parent *IssueNode, level int, config *LintConfig, report *LintReport) {
+	var visit func(node, parent *IssueNode, level int)
+	visit = func(node, parent *IssueNode, level int) {
+		target := lintTarget{Node: node, Parent: parent, Level: level}
+		report.Checked++
+
+		for _, rule := range builtinLintRules {
+			ruleConfig := config.Rules[rule.Name]
+			if !ruleConfig.isEnabled() {
+				continue
+			}
+			if message := rule.Check(target, ruleConfig); message != "" {
+				report.add(newLintFinding(rule.Name, ruleConfig.Severity, target, message))
+			}
+		}
+		for _, custom := range config.Custom {
+			if message := custom.check(target); message != "" {
+				report.add(newLintFinding(custom.Name, custom.Severity, target, message))
+			}
+		}
+
+		for _, child := range node.Children {
+			visit(child, node, level+1)
+		}
+	}
+	visit(tree, parent, level)
+}
+
+// newLintFinding builds a finding for an issue
+func newLintFinding(rule, severity string, t lintTarget, message string) LintFinding {
+	return LintFinding{
+		Rule:       rule,
+		Severity:   severity,
+		Number:     t.Node.Number,
+		Title:      t.Node.Title,
+		Repository: t.Node.Repository,
+		URL:        t.Node.URL,
+		Message:    message,
+		node:       t.Node,
+		parent:     t.Parent,
+	}
+}
+
+// add records a finding and counts it by severity
+func (r *LintReport) add(finding LintFinding) {
+	r.Findings = append(r.Findings, finding)
+	if finding.Severity == severityError {
+		r.Errors++
+	} else {
+		r.Warnings++
+	}
+}
+
+// failed reports whether the report has findings at or above the failOn severity
+func (r *LintReport) failed(failOn string) bool {
+	if failOn == severityWarning {
+		return r.Errors+r.Warnings > 0
+	}
+	return r.Errors > 0
+}
+
+// formatLintReport formats the findings for humans
+func formatLintReport(report *LintReport) string {
+	var buf bytes.Buffer
+
+	if len(report.Findings) == 0 {
+		fmt.Fprintf(&buf, "✓ No problems found in %d issues\n", report.Checked)
+		return buf.String()
+	}
+
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	for _, finding := range report.Findings {
+		fmt.Fprintf(w, "%s#%d\t%s\t%s\t%s\n",
+			finding.Repository, finding.Number, finding.Severity, finding.Rule, finding.Message)
+	}
+	w.Flush()
+
+	fmt.Fprintf(&buf, "\n✗ %d problem(s) (%d error(s), %d warning(s)) in %d issues\n",
+		len(report.Findings), report.Errors, report.Warnings, report.Checked)
+	return buf.String()
+}
+
+func runLint(cmd *cobra.Command, args []string) error {
+	// Findings are reported through the exit status, not as an error message
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+
+	if !isSeverity(lintFailOnFlag) {
+		return &ExitError{Code: 2, Err: fmt.Errorf("invalid --fail-on value: %s (expected error or warning)", lintFailOnFlag)}
+	}
+
+	config, err := loadLintConfig(lintConfigFlag)
+	if err != nil {
+		return &ExitError{Code: 2, Err: err}
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return &ExitError{Code: 2, Err: fmt.Errorf("failed to create API client: %w", err)}
+	}
+
+	report, err := collectLintReport(cmd, client, config, args)
+	if err != nil {
+		return &ExitError{Code: 2, Err: err}
+	}
+
+	if lintFixFlag {
+		fixes, remaining := applyLintFixes(newLintFixContext(client, config), report)
+
+		// The change log is the output unless JSON or a plan is printed instead
+		log := cmd.OutOrStdout()
+		if lintJSONFlag || dryRunFlag {
+			log = cmd.OutOrStderr()
+		}
+		for _, fix := range fixes {
+			fmt.Fprintln(log, formatLintFix(fix))
+		}
+		if len(fixes) > 0 {
+			fmt.Fprintln(log)
+		}
+
+		// The findings left are the report, so the plan goes next to the change log
+		if dryRunFlag {
+			if err := writePlan(cmd.OutOrStderr(), false); err != nil {
+				return &ExitError{Code: 2, Err: err}
+			}
+		}
+		report = remaining
+		report.Fixes = fixes
+	}
+
+	if lintJSONFlag {
+		if report.Findings == nil {
+			report.Findings = []LintFinding{}
+		}
+		jsonBytes, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return &ExitError{Code: 2, Err: fmt.Errorf("failed to format JSON: %w", err)}
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(jsonBytes))
+	} else {
+		fmt.Fprint(cmd.OutOrStdout(), formatLintReport(report))
+	}
+
+	if report.failed(lintFailOnFlag) {
+		return &ExitError{Code: 1}
+	}
+	return nil
+}
+
+// collectLintReport fetches the hierarchies and checks them
+func collectLintReport(cmd *cobra.Command, client *api.GraphQLClient, config *LintConfig, args []string) (*LintReport, error) {
+	// Get default repository if not specified
+	var defaultOwner, defaultRepo string
+	if lintRepoFlag != "" {
+		parts := strings.Split(lintRepoFlag, "/")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid repository format: %s (expected OWNER/REPO)", lintRepoFlag)
+		}
+		defaultOwner = parts[0]
+		defaultRepo = parts[1]
+	} else {
+		var err error
+		defaultOwner, defaultRepo, err = getDefaultRepo()
+		if err != nil {
+			return nil, fmt.Errorf("no repository specified and could not determine from current directory: %w", err)
+		}
+	}
+
+	var refs []*IssueReference
+	explicit := len(args) == 1
+	if explicit {
+		ref, err := parseIssueReference(args[0], defaultOwner, defaultRepo)
+		if err != nil {
+			return nil, fmt.Errorf("invalid issue: %w", err)
+		}
+		refs = append(refs, ref)
+	} else {
+		fmt.Fprintf(cmd.OutOrStderr(), "Finding top-level issues in %s/%s...\n", defaultOwner, defaultRepo)
+		// Closed roots are included: a closed epic with open sub-issues is a finding
+		roots, err := listRootIssues(client, defaultOwner, defaultRepo, "all")
+		if err != nil {
+			return nil, err
+		}
+		for _, root := range roots {
+			refs = append(refs, &IssueReference{Owner: defaultOwner, Repo: defaultRepo, Number: root.Number})
+		}
+	}
+
+	report := &LintReport{}
+	for _, ref := range refs {
+		fmt.Fprintf(cmd.OutOrStderr(), "Checking #%d...\n", ref.Number)
+		tree, err := getIssueTree(client, ref.Owner, ref.Repo, ref.Number, maxTreeDepth)
+		if err != nil {
+			return nil, err
+		}
+
+		// A given issue may sit anywhere in a hierarchy; listed roots are top-level
+		var ancestors []*IssueNode
+		if explicit {
+			ancestors, err = getIssueAncestors(client, tree.ID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		var parent *IssueNode
+		if len(ancestors) > 0 {
+			parent = ancestors[0]
+		}
+		lintTree(tree, parent, len(ancestors)+1, config, report)
+	}
+
+	return report, nil
+}
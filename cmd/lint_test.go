@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func lintRules(report *LintReport) []string {
+	var rules []string
+	for _, finding := range report.Findings {
+		rules = append(rules, finding.Rule)
+	}
+	return rules
+}
+
+func TestLintTreeBuiltinRules(t *testing.T) {
+	tree := &IssueNode{Number: 1, State: "closed", Labels: []string{"Epic"},
+		Milestone: &TreeMilestone{Title: "v1", DueOn: "2025-01-31T00:00:00Z"},
+		Children: []*IssueNode{
+			{Number: 2, State: "open", Assignees: []string{"alice"},
+				Milestone: &TreeMilestone{Title: "v2", DueOn: "2025-03-31T00:00:00Z"}},
+			{Number: 3, State: "open"},
+			{Number: 4, State: "closed"},
+		}}
+
+	report := &LintReport{}
+	lintTree(tree, nil, 1, defaultLintConfig(), report)
+
+	assert.Equal(t, 4, report.Checked)
+	assert.Equal(t, []string{ruleClosedParent, ruleMilestone, ruleLeafAssignee}, lintRules(report))
+	assert.Equal(t, 2, report.Findings[1].Number)
+	assert.Equal(t, "closed, but 2 sub-issue(s) are still open", report.Findings[0].Message)
+	assert.Equal(t, 3, report.Errors)
+	assert.True(t, report.failed(severityError))
+}
+
+func TestLintTreeDepthAndEpicLabel(t *testing.T) {
+	tree := &IssueNode{Number: 1, State: "open", Children: []*IssueNode{
+		{Number: 2, State: "open", Children: []*IssueNode{
+			{Number: 3, State: "open", Children: []*IssueNode{
+				{Number: 4, State: "closed"},
+			}},
+		}},
+	}}
+
+	report := &LintReport{}
+	lintTree(tree, nil, 1, defaultLintConfig(), report)
+
+	assert.Equal(t, []string{ruleEpicLabel, ruleMaxDepth}, lintRules(report))
+	assert.Equal(t, 4, report.Findings[1].Number)
+	assert.Equal(t, "hierarchy is 4 levels deep (max 3)", report.Findings[1].Message)
+}
+
+func TestLintTreeNonRoot(t *testing.T) {
+	// #2 is a sub-issue of #1, so it is at level 2 and needs no epic label
+	parent := &IssueNode{Number: 1, State: "open", Labels: []string{"epic"},
+		Milestone: &TreeMilestone{Title: "v1", DueOn: "2025-01-31T00:00:00Z"}}
+	tree := &IssueNode{Number: 2, State: "open", Assignees: []string{"alice"},
+		Milestone: &TreeMilestone{Title: "v2", DueOn: "2025-03-31T00:00:00Z"},
+		Children: []*IssueNode{
+			{Number: 3, State: "open", Assignees: []string{"alice"}, Children: []*IssueNode{
+				{Number: 4, State: "open", Assignees: []string{"bob"}},
+			}},
+		}}
+
+	report := &LintReport{}
+	lintTree(tree, parent, 2, defaultLintConfig(), report)
+
+	assert.Equal(t, 3, report.Checked)
+	assert.Equal(t, []string{ruleMilestone, ruleMaxDepth}, lintRules(report))
+	assert.Equal(t, 2, report.Findings[0].Number)
+	assert.Equal(t, 4, report.Findings[1].Number)
+	assert.Equal(t, "hierarchy is 4 levels deep (max 3)", report.Findings[1].Message)
+}
+
+func TestLintClosedRoot(t *testing.T) {
+	// Repo mode lints every root, so a closed epic with open sub-issues is found
+	issues := []RepoIssue{
+		{IssueInfo: IssueInfo{Number: 1, State: "closed"}, Total: 2, Completed: 1},
+		{IssueInfo: IssueInfo{Number: 2, State: "open"}, HasParent: true},
+		{IssueInfo: IssueInfo{Number: 3, State: "closed"}, HasParent: true},
+	}
+	roots := filterRootIssues(issues)
+	assert.Len(t, roots, 1)
+	assert.Equal(t, 1, roots[0].Number)
+
+	tree := &IssueNode{Number: 1, State: "closed", Labels: []string{"epic"}, Children: []*IssueNode{
+		{Number: 2, State: "open", Assignees: []string{"alice"}},
+		{Number: 3, State: "closed"},
+	}}
+	report := &LintReport{}
+	lintTree(tree, nil, 1, defaultLintConfig(), report)
+
+	assert.Equal(t, []string{ruleClosedParent}, lintRules(report))
+	assert.Equal(t, 1, report.Findings[0].Number)
+}
+
+func TestParseLintConfig(t *testing.T) {
+	config, err := parseLintConfig(`
+rules:
+  max-depth:
+    max: 4
+  leaf-assignee:
+    severity: warning
+  epic-label:
+    enabled: false
+custom:
+  - name: bugs-need-priority
+    when:
+      labels: bug
+    require:
+      labels: [priority]
+      assignee: true
+`)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, config.Rules[ruleMaxDepth].Max)
+	assert.Equal(t, severityError, config.Rules[ruleMaxDepth].Severity)
+	assert.Equal(t, severityWarning, config.Rules[ruleLeafAssignee].Severity)
+	assert.False(t, config.Rules[ruleEpicLabel].isEnabled())
+	assert.True(t, config.Rules[ruleClosedParent].isEnabled())
+	assert.Equal(t, "epic", config.Rules[ruleEpicLabel].Label)
+	assert.Len(t, config.Custom, 1)
+	assert.Equal(t, severityError, config.Custom[0].Severity)
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"unknown rule", "rules:\n  no-such-rule: {}\n", "unknown rule 'no-such-rule'"},
+		{"bad severity", "rules:\n  max-depth:\n    severity: fatal\n", "invalid severity 'fatal'"},
+		{"unnamed custom rule", "custom:\n  - message: x\n", "custom rule 1 has no name"},
+		{"invalid yaml", "rules: [", "invalid lint configuration"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseLintConfig(tt.content)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCustomRuleCheck(t *testing.T) {
+	leaf := true
+	rule := CustomRule{
+		Name:    "bugs-need-priority",
+		When:    lintSelector{Labels: stringList{"bug"}, State: "open", Leaf: &leaf},
+		Require: lintRequirements{Labels: stringList{"priority"}, Milestone: true},
+	}
+
+	bug := lintTarget{Node: &IssueNode{State: "open", Labels: []string{"Bug"}}}
+	assert.Equal(t, "missing label 'priority', no milestone", rule.check(bug))
+
+	// Issues the rule does not select pass
+	feature := lintTarget{Node: &IssueNode{State: "open", Labels: []string{"feature"}}}
+	assert.Equal(t, "", rule.check(feature))
+	closed := lintTarget{Node: &IssueNode{State: "closed", Labels: []string{"bug"}}}
+	assert.Equal(t, "", rule.check(closed))
+
+	// A configured message replaces the generated one
+	rule.Message = "Bugs need a priority"
+	assert.Equal(t, "Bugs need a priority", rule.check(bug))
+}
+
+func TestLintReportFailed(t *testing.T) {
+	report := &LintReport{}
+	report.add(LintFinding{Severity: severityWarning})
+
+	assert.False(t, report.failed(severityError))
+	assert.True(t, report.failed(severityWarning))
+}
+
+func TestFormatLintReport(t *testing.T) {
+	report := &LintReport{Checked: 3}
+	assert.Equal(t, "✓ No problems found in 3 issues\n", formatLintReport(report))
+
+	report.add(LintFinding{Rule: ruleLeafAssignee, Severity: severityError, Number: 2,
+		Repository: "owner/repo", Message: "open issue without sub-issues has no assignee"})
+	expected := "owner/repo#2  error  leaf-assignee  open issue without sub-issues has no assignee\n" +
+		"\n✗ 1 problem(s) (1 error(s), 0 warning(s)) in 3 issues\n"
+	assert.Equal(t, expected, formatLintReport(report))
+}
@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// LintFix is a change made (or attempted) to resolve a finding
+type LintFix struct {
+	Rule       string `json:"rule"`
+	Number     int    `json:"number"`
+	Repository string `json:"repository"`
+	Change     string `json:"change"`
+	Error      string `json:"error,omitempty"`
+}
+
+// lintFixer resolves a finding and returns a description of the change
+type lintFixer func(fc *lintFixContext, finding LintFinding) (string, error)
+
+// lintFixers are the rules that --fix can resolve
+var lintFixers = map[string]lintFixer{
+	ruleClosedParent: fixClosedParent,
+	ruleMilestone:    fixMilestone,
+	ruleEpicLabel:    fixEpicLabel,
+}
+
+// lintFixContext holds the client and the IDs resolved while fixing
+type lintFixContext struct {
+	client *api.GraphQLClient
+	config *LintConfig
+
+	// milestoneIDs and labelIDs are keyed by "owner/repo" and lowercased name
+	milestoneIDs map[string]string
+	labelIDs     map[string]string
+}
+
+func newLintFixContext(client *api.GraphQLClient, config *LintConfig) *lintFixContext {
+	return &lintFixContext{
+		client:       client,
+		config:       config,
+		milestoneIDs: make(map[string]string),
+		labelIDs:     make(map[string]string),
+	}
+}
+
+// milestoneID resolves a milestone of the issue's repository by title. The parent's
+// milestone may be closed, so closed milestones are included.
+func (fc *lintFixContext) milestoneID(repository, title string) (string, error) {
+	key := repository + "/" + strings.ToLower(title)
+	if id, ok := fc.milestoneIDs[key]; ok {
+		return id, nil
+	}
+
+	owner, repo, _ := strings.Cut(repository, "/")
+	id, err := findMilestoneID(fc.client, owner, repo, title)
+	if err != nil {
+		return "", err
+	}
+	if id == "" {
+		return "", fmt.Errorf("milestone '%s' not found in %s", title, repository)
+	}
+	fc.milestoneIDs[key] = id
+	return id, nil
+}
+
+// labelID resolves a label of the issue's repository by name
+func (fc *lintFixContext) labelID(repository, name string) (string, error) {
+	key := repository + "/" + strings.ToLower(name)
+	if id, ok := fc.labelIDs[key]; ok {
+		return id, nil
+	}
+
+	// A missing label is reported by the fix, not warned about
+	owner, repo, _ := strings.Cut(repository, "/")
+	labels, err := listLabels(fc.client, owner, repo)
+	if err != nil {
+		return "", err
+	}
+	for _, label := range labels {
+		if strings.EqualFold(label.Name, name) {
+			fc.labelIDs[key] = label.ID
+			return label.ID, nil
+		}
+	}
+	return "", fmt.Errorf("label '%s' not found in %s", name, repository)
+}
+
+// fixClosedParent reopens a closed issue that still has open sub-issues
+func fixClosedParent(fc *lintFixContext, finding LintFinding) (string, error) {
+	change := "reopened"
+	if err := reopenIssue(fc.client, finding.node.ID); err != nil {
+		return change, err
+	}
+	finding.node.State = "open"
+	finding.node.StateReason = ""
+	return change, nil
+}
+
+// fixMilestone copies the parent's milestone to the issue
+func fixMilestone(fc *lintFixContext, finding LintFinding) (string, error) {
+	node, parent := finding.node, finding.parent
+	change := fmt.Sprintf("milestone '%s' → '%s'", node.Milestone.Title, parent.Milestone.Title)
+
+	milestoneID, err := fc.milestoneID(node.Repository, parent.Milestone.Title)
+	if err != nil {
+		return change, err
+	}
+	if err := setIssueMilestone(fc.client, node.ID, milestoneID); err != nil {
+		return change, err
+	}
+	node.Milestone = parent.Milestone
+	return change, nil
+}
+
+// fixEpicLabel adds the configured epic label to a top-level issue
+func fixEpicLabel(fc *lintFixContext, finding LintFinding) (string, error) {
+	label := fc.config.Rules[ruleEpicLabel].Label
+	change := fmt.Sprintf("added label '%s'", label)
+
+	labelID, err := fc.labelID(finding.node.Repository, label)
+	if err != nil {
+		return change, err
+	}
+	if err := addLabels(fc.client, finding.node.ID, []string{labelID}); err != nil {
+		return change, err
+	}
+	finding.node.Labels = append(finding.node.Labels, label)
+	return change, nil
+}
+
+// applyLintFixes fixes every fixable finding of the report. It returns the fixes and
+// a report of the findings that remain.
+func applyLintFixes(fc *lintFixContext, report *LintReport) ([]LintFix, *LintReport) {
+	var fixes []LintFix
+	remaining := &LintReport{Checked: report.Checked}
+
+	for _, finding := range report.Findings {
+		fixer, ok := lintFixers[finding.Rule]
+		if !ok || finding.node == nil {
+			remaining.add(finding)
+			continue
+		}
+
+		change, err := fixer(fc, finding)
+		fix := LintFix{
+			Rule:       finding.Rule,
+			Number:     finding.Number,
+			Repository: finding.Repository,
+			Change:     change,
+		}
+		if err != nil {
+			fix.Error = err.Error()
+			remaining.add(finding)
+		}
+		fixes = append(fixes, fix)
+	}
+
+	return fixes, remaining
+}
+
+// formatLintFix formats a fix for the change log
+func formatLintFix(fix LintFix) string {
+	if fix.Error != "" {
+		return fmt.Sprintf("✗ %s#%d: %s failed: %s", fix.Repository, fix.Number, fix.Change, fix.Error)
+	}
+	if dryRunFlag {
+		return fmt.Sprintf("Would fix %s#%d: %s (%s)", fix.Repository, fix.Number, fix.Change, fix.Rule)
+	}
+	return fmt.Sprintf("✓ Fixed %s#%d: %s (%s)", fix.Repository, fix.Number, fix.Change, fix.Rule)
+}
+
+// setIssueMilestone sets the milestone of an issue
+func setIssueMilestone(client *api.GraphQLClient, issueID, milestoneID string) error {
+	mutation := `
+		mutation UpdateIssue($id: ID!, $milestoneId: ID!) {
+			updateIssue(input: {id: $id, milestoneId: $milestoneId}) {
+				issue {
+					number
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"id":          issueID,
+		"milestoneId": milestoneID,
+	}
+
+	var response struct {
+		UpdateIssue struct {
+			Issue struct {
+				Number int `json:"number"`
+			} `json:"issue"`
+		} `json:"updateIssue"`
+	}
+
+	err := doMutation(client, "updateIssue", "Set issue milestone", mutation, variables, &response)
+	if err != nil {
+		return fmt.Errorf("failed to set milestone: %w", err)
+	}
+
+	return nil
+}
+
+// addLabels adds labels to an issue
+func addLabels(client *api.GraphQLClient, issueID string, labelIDs []string) error {
+	mutation := `
+		mutation AddLabels($id: ID!, $labelIds: [ID!]!) {
+			addLabelsToLabelable(input: {labelableId: $id, labelIds: $labelIds}) {
+				clientMutationId
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"id":       issueID,
+		"labelIds": labelIDs,
+	}
+
+	var response struct {
+		AddLabelsToLabelable struct {
+			ClientMutationID string `json:"clientMutationId"`
+		} `json:"addLabelsToLabelable"`
+	}
+
+	err := doMutation(client, "addLabelsToLabelable", "Add labels", mutation, variables, &response)
+	if err != nil {
+		return fmt.Errorf("failed to add labels: %w", err)
+	}
+
+	return nil
+}
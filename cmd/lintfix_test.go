@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyLintFixesDryRun(t *testing.T) {
+	withDryRun(t)
+
+	tree := &IssueNode{ID: "I1", Number: 1, State: "closed", Repository: "owner/repo",
+		Milestone: &TreeMilestone{Title: "v1", DueOn: "2025-01-31T00:00:00Z"},
+		Children: []*IssueNode{
+			{ID: "I2", Number: 2, State: "open", Repository: "owner/repo",
+				Milestone: &TreeMilestone{Title: "v2", DueOn: "2025-03-31T00:00:00Z"}},
+		}}
+
+	report := &LintReport{}
+	lintTree(tree, nil, 1, defaultLintConfig(), report)
+	assert.Equal(t, []string{ruleClosedParent, ruleEpicLabel, ruleMilestone, ruleLeafAssignee}, lintRules(report))
+
+	// IDs are resolved up front, so no client is needed
+	fc := newLintFixContext(nil, defaultLintConfig())
+	fc.milestoneIDs["owner/repo/v1"] = "M1"
+	fc.labelIDs["owner/repo/epic"] = "L1"
+
+	fixes, remaining := applyLintFixes(fc, report)
+
+	assert.Len(t, fixes, 3)
+	assert.Equal(t, "reopened", fixes[0].Change)
+	assert.Equal(t, "added label 'epic'", fixes[1].Change)
+	assert.Equal(t, "milestone 'v2' → 'v1'", fixes[2].Change)
+	assert.Equal(t, []string{ruleLeafAssignee}, lintRules(remaining))
+	assert.Equal(t, 2, remaining.Checked)
+
+	assert.Len(t, plan.Mutations, 3)
+	assert.Equal(t, "reopenIssue", plan.Mutations[0].Name)
+	assert.Equal(t, "addLabelsToLabelable", plan.Mutations[1].Name)
+	assert.Equal(t, []string{"L1"}, plan.Mutations[1].Variables["labelIds"])
+	assert.Equal(t, "updateIssue", plan.Mutations[2].Name)
+	assert.Equal(t, "M1", plan.Mutations[2].Variables["milestoneId"])
+
+	// The tree reflects the fixes
+	assert.Equal(t, "open", tree.State)
+	assert.Equal(t, []string{"epic"}, tree.Labels)
+	assert.Equal(t, "v1", tree.Children[0].Milestone.Title)
+}
+
+func TestLintFixLabelID(t *testing.T) {
+	client, err := api.NewGraphQLClient(api.ClientOptions{
+		Host:      "github.com",
+		AuthToken: "token",
+		Transport: graphQLResponder(`{"data": {"repository": {"labels": {"nodes": [{"id": "L1", "name": "Epic"}]}}}}`),
+	})
+	assert.NoError(t, err)
+	fc := newLintFixContext(client, defaultLintConfig())
+
+	id, err := fc.labelID("owner/repo", "epic")
+	assert.NoError(t, err)
+	assert.Equal(t, "L1", id)
+
+	// The missing label is the error of the fix
+	_, err = fc.labelID("owner/repo", "initiative")
+	assert.EqualError(t, err, "label 'initiative' not found in owner/repo")
+}
+
+func TestFormatLintFix(t *testing.T) {
+	fix := LintFix{Rule: ruleEpicLabel, Number: 1, Repository: "owner/repo", Change: "added label 'epic'"}
+	assert.Equal(t, "✓ Fixed owner/repo#1: added label 'epic' (epic-label)", formatLintFix(fix))
+
+	fix.Error = "label 'epic' not found in owner/repo"
+	assert.Equal(t, "✗ owner/repo#1: added label 'epic' failed: label 'epic' not found in owner/repo", formatLintFix(fix))
+}
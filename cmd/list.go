@@ -17,6 +17,7 @@ var (
 	listJSONFlag   string
 	listWebFlag    bool
 	listRepoFlag   string
+	listTypeFlag   string
 )
 
 var listCmd = &cobra.Command{
@@ -45,6 +46,9 @@ Examples:
   # JSON output with parent and meta info
   gh sub-issues list 123 --json parent.number,parent.title,total,openCount
   
+  # Filter by issue type
+  gh sub-issues list 123 --type Task
+  
   # Limit results
   gh sub-issues list 123 --limit 10`,
 	Args: cobra.ExactArgs(1),
@@ -61,6 +65,7 @@ func init() {
 	listCmd.Flags().StringVar(&listJSONFlag, "json", "", "Output JSON with the specified fields")
 	listCmd.Flags().BoolVarP(&listWebFlag, "web", "w", false, "Open in web browser")
 	listCmd.Flags().StringVarP(&listRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+	listCmd.Flags().StringVar(&listTypeFlag, "type", "", "Filter by issue type")
 }
 
 // SubIssue represents a sub-issue
@@ -69,6 +74,7 @@ type SubIssue struct {
 	Title     string   `json:"title"`
 	State     string   `json:"state"`
 	URL       string   `json:"url"`
+	Type      string   `json:"type,omitempty"`
 	Assignees []string `json:"assignees,omitempty"`
 }
 
@@ -87,8 +93,9 @@ type ListResult struct {
 	OpenCount int         `json:"openCount"`
 }
 
-// getSubIssues fetches sub-issues for a parent issue
-func getSubIssues(client *api.GraphQLClient, owner, repo string, number int, limit int) (*ListResult, error) {
+// getSubIssues fetches up to limit sub-issues for a parent issue, or all of them when limit
+// is 0, keeping those with the given state ("all" for any) and type ("" for any)
+func getSubIssues(client *api.GraphQLClient, owner, repo string, number int, limit int, state, issueType string) (*ListResult, error) {
 	// First, get the parent issue details
 	parentQuery := `
 		query($owner: String!, $repo: String!, $number: Int!) {
@@ -128,17 +135,24 @@ func getSubIssues(client *api.GraphQLClient, owner, repo string, number int, lim
 		return nil, fmt.Errorf("issue #%d not found in %s/%s", number, owner, repo)
 	}
 	
-	// Now get the sub-issues using the subIssues field
+	// Now get the sub-issues using the subIssues field, a page at a time
 	subIssuesQuery := `
-		query($owner: String!, $repo: String!, $number: Int!, $limit: Int!) {
+		query($owner: String!, $repo: String!, $number: Int!, $limit: Int!, $cursor: String) {
 			repository(owner: $owner, name: $repo) {
 				issue(number: $number) {
-					subIssues(first: $limit) {
+					subIssues(first: $limit, after: $cursor) {
+						pageInfo {
+							hasNextPage
+							endCursor
+						}
 						nodes {
 							number
 							title
 							state
 							url
+							issueType {
+								name
+							}
 							assignees(first: 10) {
 								nodes {
 									login
@@ -150,36 +164,63 @@ func getSubIssues(client *api.GraphQLClient, owner, repo string, number int, lim
 			}
 		}`
 	
-	var subIssuesResponse struct {
-		Repository struct {
-			Issue struct {
-				SubIssues struct {
-					Nodes []struct {
-						Number    int    `json:"number"`
-						Title     string `json:"title"`
-						State     string `json:"state"`
-						URL       string `json:"url"`
-						Assignees struct {
-							Nodes []struct {
-								Login string `json:"login"`
-							} `json:"nodes"`
-						} `json:"assignees"`
-					} `json:"nodes"`
-				} `json:"subIssues"`
-			} `json:"issue"`
-		} `json:"repository"`
+	type subIssueNode struct {
+		Number    int    `json:"number"`
+		Title     string `json:"title"`
+		State     string `json:"state"`
+		URL       string `json:"url"`
+		IssueType *struct {
+			Name string `json:"name"`
+		} `json:"issueType"`
+		Assignees struct {
+			Nodes []struct {
+				Login string `json:"login"`
+			} `json:"nodes"`
+		} `json:"assignees"`
 	}
 	
-	subVariables := map[string]interface{}{
-		"owner":  owner,
-		"repo":   repo,
-		"number": number,
-		"limit":  limit,
-	}
-	
-	err = client.Do(subIssuesQuery, subVariables, &subIssuesResponse)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get sub-issues: %w", err)
+	var nodes []subIssueNode
+	var cursor *string
+	for limit <= 0 || len(nodes) < limit {
+		pageSize := 100
+		if limit > 0 && limit-len(nodes) < pageSize {
+			pageSize = limit - len(nodes)
+		}
+		
+		var subIssuesResponse struct {
+			Repository struct {
+				Issue struct {
+					SubIssues struct {
+						PageInfo struct {
+							HasNextPage bool   `json:"hasNextPage"`
+							EndCursor   string `json:"endCursor"`
+						} `json:"pageInfo"`
+						Nodes []subIssueNode `json:"nodes"`
+					} `json:"subIssues"`
+				} `json:"issue"`
+			} `json:"repository"`
+		}
+		
+		subVariables := map[string]interface{}{
+			"owner":  owner,
+			"repo":   repo,
+			"number": number,
+			"limit":  pageSize,
+			"cursor": cursor,
+		}
+		
+		err = client.Do(subIssuesQuery, subVariables, &subIssuesResponse)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get sub-issues: %w", err)
+		}
+		
+		page := subIssuesResponse.Repository.Issue.SubIssues
+		nodes = append(nodes, page.Nodes...)
+		if !page.PageInfo.HasNextPage {
+			break
+		}
+		endCursor := page.PageInfo.EndCursor
+		cursor = &endCursor
 	}
 	
 	// Build result
@@ -195,7 +236,7 @@ func getSubIssues(client *api.GraphQLClient, owner, repo string, number int, lim
 	}
 	
 	// Process sub-issues
-	for _, node := range subIssuesResponse.Repository.Issue.SubIssues.Nodes {
+	for _, node := range nodes {
 		if node.Number == 0 {
 			continue // Skip if not an issue
 		}
@@ -212,12 +253,13 @@ func getSubIssues(client *api.GraphQLClient, owner, repo string, number int, lim
 			URL:       node.URL,
 			Assignees: assignees,
 		}
+		if node.IssueType != nil {
+			subIssue.Type = node.IssueType.Name
+		}
 		
-		// Apply state filter
-		if listStateFlag != "all" {
-			if listStateFlag != subIssue.State {
-				continue
-			}
+		// Apply state and type filters
+		if !matchesListFilters(subIssue, state, issueType) {
+			continue
 		}
 		
 		result.SubIssues = append(result.SubIssues, subIssue)
@@ -231,6 +273,18 @@ func getSubIssues(client *api.GraphQLClient, owner, repo string, number int, lim
 	return result, nil
 }
 
+// matchesListFilters reports whether a sub-issue has the given state ("all" for any)
+// and type ("" for any)
+func matchesListFilters(issue SubIssue, state, issueType string) bool {
+	if state != "all" && state != issue.State {
+		return false
+	}
+	if issueType != "" && !strings.EqualFold(issueType, issue.Type) {
+		return false
+	}
+	return true
+}
+
 // formatTTY formats output for terminal with colors
 func formatTTY(result *ListResult) string {
 	var output strings.Builder
@@ -302,6 +356,7 @@ func formatJSONWithFields(result *ListResult, fields []string) (string, error) {
 		"title":         true,
 		"state":         true,
 		"url":           true,
+		"type":          true,
 		"assignees":     true,
 		"parent.number": true,
 		"parent.title":  true,
@@ -312,7 +367,7 @@ func formatJSONWithFields(result *ListResult, fields []string) (string, error) {
 	
 	for _, field := range fields {
 		if !validFields[field] {
-			return "", fmt.Errorf("invalid field: %s. Valid fields are: number, title, state, url, assignees, parent.number, parent.title, parent.state, total, openCount", field)
+			return "", fmt.Errorf("invalid field: %s. Valid fields are: number, title, state, url, type, assignees, parent.number, parent.title, parent.state, total, openCount", field)
 		}
 	}
 	
@@ -349,7 +404,7 @@ func formatJSONWithFields(result *ListResult, fields []string) (string, error) {
 	}
 	
 	// Add sub-issues with selected fields
-	if fieldSet["number"] || fieldSet["title"] || fieldSet["state"] || fieldSet["url"] || fieldSet["assignees"] {
+	if fieldSet["number"] || fieldSet["title"] || fieldSet["state"] || fieldSet["url"] || fieldSet["type"] || fieldSet["assignees"] {
 		var subIssues []map[string]interface{}
 		for _, issue := range result.SubIssues {
 			subIssue := make(map[string]interface{})
@@ -365,6 +420,9 @@ func formatJSONWithFields(result *ListResult, fields []string) (string, error) {
 			if fieldSet["url"] {
 				subIssue["url"] = issue.URL
 			}
+			if fieldSet["type"] {
+				subIssue["type"] = issue.Type
+			}
 			if fieldSet["assignees"] {
 				subIssue["assignees"] = issue.Assignees
 			}
@@ -432,7 +490,7 @@ func runList(cmd *cobra.Command, args []string) error {
 	}
 	
 	// Get sub-issues
-	result, err := getSubIssues(client, parentRef.Owner, parentRef.Repo, parentRef.Number, listLimitFlag)
+	result, err := getSubIssues(client, parentRef.Owner, parentRef.Repo, parentRef.Number, listLimitFlag, listStateFlag, listTypeFlag)
 	if err != nil {
 		return err
 	}
@@ -444,7 +502,7 @@ func runList(cmd *cobra.Command, args []string) error {
 		// JSON output requires field specification
 		if listJSONFlag == "" {
 			// Print available fields when no fields specified
-			fmt.Fprintln(cmd.OutOrStderr(), "Specify one or more comma-separated fields for `--json`:\n  assignees\n  number\n  openCount\n  parent.number\n  parent.state\n  parent.title\n  state\n  title\n  total\n  url")
+			fmt.Fprintln(cmd.OutOrStderr(), "Specify one or more comma-separated fields for `--json`:\n  assignees\n  number\n  openCount\n  parent.number\n  parent.state\n  parent.title\n  state\n  title\n  total\n  type\n  url")
 			return fmt.Errorf("")
 		}
 		
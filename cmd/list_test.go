@@ -368,4 +368,59 @@ func TestFormatJSONWithFields(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestFormatJSONWithTypeField(t *testing.T) {
+	result := &ListResult{
+		Parent: ParentIssue{Number: 1, Title: "Epic", State: "open"},
+		SubIssues: []SubIssue{
+			{Number: 2, Title: "Feature", State: "open", Type: "Feature"},
+			{Number: 3, Title: "Untyped", State: "open"},
+		},
+		Total:     2,
+		OpenCount: 2,
+	}
+
+	output, err := formatJSONWithFields(result, []string{"number", "type"})
+	if err != nil {
+		t.Fatalf("formatJSONWithFields() unexpected error: %v", err)
+	}
+
+	var parsed map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &parsed); err != nil {
+		t.Fatalf("formatJSONWithFields() produced invalid JSON: %v", err)
+	}
+
+	subIssues := parsed["subIssues"].([]interface{})
+	if subIssues[0].(map[string]interface{})["type"] != "Feature" {
+		t.Errorf("Expected type 'Feature', got %v", subIssues[0].(map[string]interface{})["type"])
+	}
+	if subIssues[1].(map[string]interface{})["type"] != "" {
+		t.Errorf("Expected empty type, got %v", subIssues[1].(map[string]interface{})["type"])
+	}
+}
+
+func TestMatchesListFilters(t *testing.T) {
+	tests := []struct {
+		name      string
+		state     string
+		issueType string
+		issue     SubIssue
+		expected  bool
+	}{
+		{"open matches open", "open", "", SubIssue{State: "open"}, true},
+		{"open excludes closed", "open", "", SubIssue{State: "closed"}, false},
+		{"all includes closed", "all", "", SubIssue{State: "closed"}, true},
+		{"type matches case-insensitively", "all", "task", SubIssue{State: "open", Type: "Task"}, true},
+		{"type excludes other types", "all", "Bug", SubIssue{State: "open", Type: "Task"}, false},
+		{"type excludes untyped issues", "all", "Bug", SubIssue{State: "open"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesListFilters(tt.issue, tt.state, tt.issueType); got != tt.expected {
+				t.Errorf("matchesListFilters() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
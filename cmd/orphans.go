@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/spf13/cobra"
+)
+
+var (
+	orphansRepoFlag           string
+	orphansLabelFlag          []string
+	orphansMilestoneFlag      string
+	orphansStateFlag          string
+	orphansExcludeParentsFlag bool
+	orphansAssignToFlag       string
+	orphansForceFlag          bool
+	orphansJSONFlag           bool
+)
+
+var orphansCmd = &cobra.Command{
+	Use:   "orphans",
+	Short: "List issues that have no parent issue",
+	Long: `List the issues of a repository that are not a sub-issue of any other issue.
+
+With --assign-to, every orphan found is linked as a sub-issue of the given
+parent issue, after confirmation unless --force is given.
+
+Examples:
+  # Open issues without a parent
+  gh sub-issue orphans --repo owner/repo
+
+  # Only bugs of a milestone, leaving out issues that have sub-issues themselves
+  gh sub-issue orphans --label bug --milestone v2.0 --exclude-parents
+
+  # Move all of them under epic #123
+  gh sub-issue orphans --label bug --assign-to 123`,
+	Args: cobra.NoArgs,
+	RunE: runOrphans,
+}
+
+func init() {
+	rootCmd.AddCommand(orphansCmd)
+	orphansCmd.Flags().StringVarP(&orphansRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+	orphansCmd.Flags().StringSliceVarP(&orphansLabelFlag, "label", "l", nil, "Only issues with these labels")
+	orphansCmd.Flags().StringVarP(&orphansMilestoneFlag, "milestone", "m", "", "Only issues in this milestone")
+	orphansCmd.Flags().StringVarP(&orphansStateFlag, "state", "s", "open", "Filter by state: {open|closed|all}")
+	orphansCmd.Flags().BoolVar(&orphansExcludeParentsFlag, "exclude-parents", false, "Leave out issues that have sub-issues")
+	orphansCmd.Flags().StringVar(&orphansAssignToFlag, "assign-to", "", "Link the orphans as sub-issues of this parent issue")
+	orphansCmd.Flags().BoolVarP(&orphansForceFlag, "force", "f", false, "Skip confirmation prompt for --assign-to")
+	orphansCmd.Flags().BoolVar(&orphansJSONFlag, "json", false, "Output results as JSON")
+}
+
+// orphanFilter selects the orphans to report
+type orphanFilter struct {
+	Labels         []string
+	Milestone      string
+	ExcludeParents bool
+}
+
+// filterOrphans returns the issues without a parent that match the filter
+func filterOrphans(issues []RepoIssue, filter orphanFilter) []RepoIssue {
+	var orphans []RepoIssue
+	for _, issue := range issues {
+		if issue.HasParent {
+			continue
+		}
+		if filter.ExcludeParents && issue.Total > 0 {
+			continue
+		}
+		if filter.Milestone != "" && !strings.EqualFold(issue.Milestone, filter.Milestone) {
+			continue
+		}
+		matches := true
+		for _, label := range filter.Labels {
+			if !hasLabel(issue.Labels, label) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			orphans = append(orphans, issue)
+		}
+	}
+	return orphans
+}
+
+// formatOrphans formats orphans as a table
+func formatOrphans(orphans []RepoIssue) string {
+	var output strings.Builder
+
+	w := tabwriter.NewWriter(&output, 0, 0, 2, ' ', 0)
+	for _, issue := range orphans {
+		fmt.Fprintf(w, "#%d\t%s\t%s\t%s\n",
+			issue.Number, issue.State, truncate(issue.Title, 60), strings.Join(issue.Labels, ", "))
+	}
+	w.Flush()
+
+	return output.String()
+}
+
+func runOrphans(cmd *cobra.Command, args []string) error {
+	switch orphansStateFlag {
+	case "open", "closed", "all":
+	default:
+		return fmt.Errorf("invalid state: %s (expected open, closed or all)", orphansStateFlag)
+	}
+
+	// Get default repository if not specified
+	var defaultOwner, defaultRepo string
+	if orphansRepoFlag != "" {
+		parts := strings.Split(orphansRepoFlag, "/")
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid repository format: %s (expected OWNER/REPO)", orphansRepoFlag)
+		}
+		defaultOwner = parts[0]
+		defaultRepo = parts[1]
+	} else {
+		var err error
+		defaultOwner, defaultRepo, err = getDefaultRepo()
+		if err != nil {
+			return fmt.Errorf("no repository specified and could not determine from current directory: %w", err)
+		}
+	}
+
+	var parentRef *IssueReference
+	if orphansAssignToFlag != "" {
+		var err error
+		parentRef, err = parseIssueReference(orphansAssignToFlag, defaultOwner, defaultRepo)
+		if err != nil {
+			return fmt.Errorf("invalid parent issue: %w", err)
+		}
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStderr(), "Finding issues without a parent in %s/%s...\n", defaultOwner, defaultRepo)
+	issues, err := listRepoIssues(client, defaultOwner, defaultRepo, orphansStateFlag)
+	if err != nil {
+		return err
+	}
+	orphans := filterOrphans(issues, orphanFilter{
+		Labels:         orphansLabelFlag,
+		Milestone:      orphansMilestoneFlag,
+		ExcludeParents: orphansExcludeParentsFlag,
+	})
+
+	if parentRef != nil {
+		return assignOrphans(cmd, client, parentRef, defaultOwner, defaultRepo, orphans)
+	}
+
+	if orphansJSONFlag {
+		if orphans == nil {
+			orphans = []RepoIssue{}
+		}
+		jsonBytes, err := json.MarshalIndent(orphans, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(jsonBytes))
+		return nil
+	}
+
+	if len(orphans) == 0 {
+		fmt.Fprintln(cmd.OutOrStderr(), "No issues without a parent found.")
+		return nil
+	}
+	fmt.Fprint(cmd.OutOrStdout(), formatOrphans(orphans))
+	return nil
+}
+
+// assignOrphans links the orphans as sub-issues of the parent
+func assignOrphans(cmd *cobra.Command, client *api.GraphQLClient, parentRef *IssueReference, owner, repo string, orphans []RepoIssue) error {
+	fmt.Fprintf(cmd.OutOrStderr(), "Getting parent issue #%d from %s/%s...\n",
+		parentRef.Number, parentRef.Owner, parentRef.Repo)
+	parent, err := getIssue(client, parentRef.Owner, parentRef.Repo, parentRef.Number)
+	if err != nil {
+		return err
+	}
+
+	// The parent, or the top of its hierarchy, may itself be an orphan of the repository.
+	// Linking it below the parent would create a cycle.
+	ancestors, err := getIssueAncestors(client, parent.ID)
+	if err != nil {
+		return err
+	}
+	excluded := map[string]bool{parent.ID: true}
+	for _, ancestor := range ancestors {
+		excluded[ancestor.ID] = true
+	}
+	var candidates []RepoIssue
+	for _, orphan := range orphans {
+		if !excluded[orphan.ID] {
+			candidates = append(candidates, orphan)
+		}
+	}
+
+	// Get confirmation if not forced (nothing is changed in dry-run mode)
+	if len(candidates) > 0 && !orphansForceFlag && !dryRunFlag {
+		fmt.Fprintf(cmd.OutOrStderr(), "Are you sure you want to add %d issue(s) as sub-issues of #%d? (y/N): ",
+			len(candidates), parent.Number)
+		var response string
+		fmt.Scanln(&response)
+		if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
+			fmt.Fprintln(cmd.OutOrStderr(), "Assignment cancelled")
+			return nil
+		}
+	}
+
+	result := &MutationResult{Parent: newIssueResult(parentRef, parent)}
+	var errors []error
+
+	for _, orphan := range candidates {
+		ref := &IssueReference{Owner: owner, Repo: repo, Number: orphan.Number}
+		info := orphan.IssueInfo
+
+		fmt.Fprintf(cmd.OutOrStderr(), "Linking #%d...\n", orphan.Number)
+		if _, _, err := addSubIssue(client, parent.ID, orphan.ID); err != nil {
+			errors = append(errors, fmt.Errorf("#%d: %w", orphan.Number, err))
+			result.SubIssues = append(result.SubIssues, failedIssueResult(ref, &info, err))
+			continue
+		}
+
+		subResult := newIssueResult(ref, &info)
+		subResult.Title = orphan.Title
+		subResult.Status = statusAdded
+		result.SubIssues = append(result.SubIssues, subResult)
+	}
+
+	if dryRunFlag {
+		return writePlan(cmd.OutOrStdout(), orphansJSONFlag || !term.IsTerminal(os.Stdout))
+	}
+
+	if orphansJSONFlag {
+		if err := writeMutationResult(cmd.OutOrStdout(), result); err != nil {
+			return err
+		}
+	} else {
+		for _, sub := range result.SubIssues {
+			if sub.Status == statusAdded {
+				fmt.Fprintf(cmd.OutOrStdout(), "✓ Added issue #%d as a sub-issue of #%d\n", sub.Number, parent.Number)
+			}
+		}
+		if len(result.SubIssues) == 0 {
+			fmt.Fprintln(cmd.OutOrStdout(), "No issues without a parent found.")
+		}
+	}
+
+	// Display errors if any
+	if len(errors) > 0 {
+		fmt.Fprintln(cmd.OutOrStderr(), "\nErrors encountered:")
+		for _, err := range errors {
+			fmt.Fprintf(cmd.OutOrStderr(), "  - %v\n", err)
+		}
+		return fmt.Errorf("failed to add %d issue(s)", len(errors))
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterOrphans(t *testing.T) {
+	issues := []RepoIssue{
+		{IssueInfo: IssueInfo{Number: 1}, Labels: []string{"Bug"}, Milestone: "v2.0"},
+		{IssueInfo: IssueInfo{Number: 2}, Labels: []string{"bug"}, HasParent: true},
+		{IssueInfo: IssueInfo{Number: 3}, Labels: []string{"bug", "ui"}, Total: 2},
+		{IssueInfo: IssueInfo{Number: 4}, Labels: []string{"feature"}, Milestone: "v2.0"},
+	}
+
+	orphanNumbers := func(filter orphanFilter) []int {
+		var result []int
+		for _, issue := range filterOrphans(issues, filter) {
+			result = append(result, issue.Number)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name   string
+		filter orphanFilter
+		want   []int
+	}{
+		{"no filter", orphanFilter{}, []int{1, 3, 4}},
+		{"label", orphanFilter{Labels: []string{"bug"}}, []int{1, 3}},
+		{"all labels", orphanFilter{Labels: []string{"bug", "ui"}}, []int{3}},
+		{"milestone", orphanFilter{Milestone: "V2.0"}, []int{1, 4}},
+		{"exclude parents", orphanFilter{ExcludeParents: true}, []int{1, 4}},
+		{"no match", orphanFilter{Labels: []string{"docs"}}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, orphanNumbers(tt.filter))
+		})
+	}
+}
+
+func TestFormatOrphans(t *testing.T) {
+	orphans := []RepoIssue{
+		{IssueInfo: IssueInfo{Number: 7, Title: "Fix login", State: "open"}, Labels: []string{"bug", "ui"}},
+		{IssueInfo: IssueInfo{Number: 12, Title: "Docs", State: "closed"}, Labels: []string{}},
+	}
+
+	expected := "#7   open    Fix login  bug, ui\n" +
+		"#12  closed  Docs       \n"
+	assert.Equal(t, expected, formatOrphans(orphans))
+}
@@ -0,0 +1,304 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/spf13/cobra"
+)
+
+var (
+	progressRepoFlag        string
+	progressJSONFlag        bool
+	progressWeightLabelFlag string
+	progressWeightFieldFlag string
+)
+
+var progressCmd = &cobra.Command{
+	Use:   "progress <issue>",
+	Short: "Show completion of an issue across its whole hierarchy",
+	Long: `Show how much of an issue is done, counting the leaf issues of the whole
+sub-issue tree. Each direct sub-issue gets a progress bar for its branch.
+
+By default every leaf issue counts as 1. Use --weight-label to weigh issues by a
+label such as "points: 3", or --weight-field to use a number field of the
+issue's Projects V2 items (e.g. "Estimate"). Leaf issues without a weight count as 1.
+
+Examples:
+  # Completion of epic #123
+  gh sub-issue progress 123
+
+  # Weighted by "points: N" labels
+  gh sub-issue progress 123 --weight-label "points:"
+
+  # Weighted by a project number field
+  gh sub-issue progress 123 --weight-field Estimate
+
+  # JSON for dashboards
+  gh sub-issue progress 123 --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProgress,
+}
+
+func init() {
+	rootCmd.AddCommand(progressCmd)
+	progressCmd.Flags().StringVarP(&progressRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+	progressCmd.Flags().BoolVar(&progressJSONFlag, "json", false, "Output progress as JSON")
+	progressCmd.Flags().StringVar(&progressWeightLabelFlag, "weight-label", "", "Weigh issues by the number in labels with this prefix (e.g. \"points:\")")
+	progressCmd.Flags().StringVar(&progressWeightFieldFlag, "weight-field", "", "Weigh issues by a Projects V2 number field")
+	progressCmd.MarkFlagsMutuallyExclusive("weight-label", "weight-field")
+}
+
+// Progress is the completion of an issue and its sub-issue branches
+type Progress struct {
+	Number   int         `json:"number"`
+	Title    string      `json:"title"`
+	State    string      `json:"state"`
+	URL      string      `json:"url"`
+	Done     float64     `json:"done"`
+	Total    float64     `json:"total"`
+	Percent  int         `json:"percent"`
+	Children []*Progress `json:"children,omitempty"`
+}
+
+// computeProgress sums the weight of closed leaf issues below node
+func computeProgress(node *IssueNode, weight func(*IssueNode) float64) *Progress {
+	progress := &Progress{
+		Number: node.Number,
+		Title:  node.Title,
+		State:  node.State,
+		URL:    node.URL,
+	}
+
+	if len(node.Children) == 0 {
+		progress.Total = weight(node)
+		if node.State == "closed" {
+			progress.Done = progress.Total
+		}
+	}
+	for _, child := range node.Children {
+		childProgress := computeProgress(child, weight)
+		progress.Done += childProgress.Done
+		progress.Total += childProgress.Total
+		progress.Children = append(progress.Children, childProgress)
+	}
+
+	if progress.Total > 0 {
+		progress.Percent = int(progress.Done * 100 / progress.Total)
+	}
+	return progress
+}
+
+// labelWeight returns the number in the first label starting with prefix, e.g. "points: 3"
+func labelWeight(labels []string, prefix string) (float64, bool) {
+	for _, label := range labels {
+		if len(label) < len(prefix) || !strings.EqualFold(label[:len(prefix)], prefix) {
+			continue
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(label[len(prefix):]), 64)
+		if err == nil && value >= 0 {
+			return value, true
+		}
+	}
+	return 0, false
+}
+
+// progressBar renders a bar of the given width filled to percent
+func progressBar(percent, width int) string {
+	filled := percent * width / 100
+	if filled > width {
+		filled = width
+	}
+	return strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+}
+
+// formatAmount prints whole numbers without decimals
+func formatAmount(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// formatProgressTTY formats progress with bars for the terminal
+func formatProgressTTY(progress *Progress, unit string) string {
+	var output strings.Builder
+
+	output.WriteString(fmt.Sprintf("\n#%d - %s\n", progress.Number, progress.Title))
+	output.WriteString(fmt.Sprintf("%s %3d%%  (%s of %s %s done)\n\n", progressBar(progress.Percent, 30),
+		progress.Percent, formatAmount(progress.Done), formatAmount(progress.Total), unit))
+
+	if len(progress.Children) == 0 {
+		output.WriteString("No sub-issues found.\n")
+		return output.String()
+	}
+
+	for _, child := range progress.Children {
+		output.WriteString(fmt.Sprintf("#%-5d %-40s %s %3d%%  %s/%s\n",
+			child.Number, truncate(child.Title, 40), progressBar(child.Percent, 20),
+			child.Percent, formatAmount(child.Done), formatAmount(child.Total)))
+	}
+
+	return output.String()
+}
+
+// formatProgressPlain formats one tab-separated line per direct sub-issue, then the total
+func formatProgressPlain(progress *Progress) string {
+	var output strings.Builder
+
+	for _, child := range progress.Children {
+		output.WriteString(fmt.Sprintf("%d\t%s\t%s\t%s\t%s\t%d\n",
+			child.Number, child.State, child.Title, formatAmount(child.Done), formatAmount(child.Total), child.Percent))
+	}
+	output.WriteString(fmt.Sprintf("%d\t%s\t%s\t%s\t%s\t%d\n",
+		progress.Number, progress.State, progress.Title, formatAmount(progress.Done), formatAmount(progress.Total), progress.Percent))
+
+	return output.String()
+}
+
+// formatProgressJSON formats the whole progress tree as JSON
+func formatProgressJSON(progress *Progress) (string, error) {
+	jsonBytes, err := json.MarshalIndent(progress, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(jsonBytes) + "\n", nil
+}
+
+func runProgress(cmd *cobra.Command, args []string) error {
+	// Get default repository if not specified
+	var defaultOwner, defaultRepo string
+	if progressRepoFlag != "" {
+		parts := strings.Split(progressRepoFlag, "/")
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid repository format: %s (expected OWNER/REPO)", progressRepoFlag)
+		}
+		defaultOwner = parts[0]
+		defaultRepo = parts[1]
+	} else {
+		var err error
+		defaultOwner, defaultRepo, err = getDefaultRepo()
+		if err != nil {
+			return fmt.Errorf("no repository specified and could not determine from current directory: %w", err)
+		}
+	}
+
+	issueRef, err := parseIssueReference(args[0], defaultOwner, defaultRepo)
+	if err != nil {
+		return fmt.Errorf("invalid issue: %w", err)
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStderr(), "Getting sub-issue tree of #%d from %s/%s...\n",
+		issueRef.Number, issueRef.Owner, issueRef.Repo)
+	tree, err := getIssueTree(client, issueRef.Owner, issueRef.Repo, issueRef.Number, maxTreeDepth)
+	if err != nil {
+		return err
+	}
+
+	unit := "issues"
+	weight := func(*IssueNode) float64 { return 1 }
+
+	if progressWeightLabelFlag != "" {
+		unit = "points"
+		weight = func(node *IssueNode) float64 {
+			if value, ok := labelWeight(node.Labels, progressWeightLabelFlag); ok {
+				return value
+			}
+			return 1
+		}
+	}
+
+	if progressWeightFieldFlag != "" {
+		unit = strings.ToLower(progressWeightFieldFlag)
+		fmt.Fprintf(cmd.OutOrStderr(), "Getting '%s' of leaf issues...\n", progressWeightFieldFlag)
+		values := make(map[string]float64)
+		for _, leaf := range tree.Leaves() {
+			value, ok, err := getProjectNumberField(client, leaf.ID, progressWeightFieldFlag)
+			if err != nil {
+				return err
+			}
+			if ok {
+				values[leaf.ID] = value
+			}
+		}
+		weight = func(node *IssueNode) float64 {
+			if value, ok := values[node.ID]; ok {
+				return value
+			}
+			return 1
+		}
+	}
+
+	progress := computeProgress(tree, weight)
+
+	var output string
+	if progressJSONFlag {
+		output, err = formatProgressJSON(progress)
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+	} else if term.IsTerminal(os.Stdout) {
+		output = formatProgressTTY(progress, unit)
+	} else {
+		output = formatProgressPlain(progress)
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), output)
+	return nil
+}
+
+// getProjectNumberField gets a number field of the first project item of an issue that has it set
+func getProjectNumberField(client *api.GraphQLClient, issueID, field string) (float64, bool, error) {
+	query := `
+		query($id: ID!, $field: String!) {
+			node(id: $id) {
+				... on Issue {
+					projectItems(first: 20) {
+						nodes {
+							fieldValueByName(name: $field) {
+								... on ProjectV2ItemFieldNumberValue {
+									number
+								}
+							}
+						}
+					}
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"id":    issueID,
+		"field": field,
+	}
+
+	var response struct {
+		Node struct {
+			ProjectItems struct {
+				Nodes []struct {
+					FieldValueByName *struct {
+						Number *float64 `json:"number"`
+					} `json:"fieldValueByName"`
+				} `json:"nodes"`
+			} `json:"projectItems"`
+		} `json:"node"`
+	}
+
+	err := client.Do(query, variables, &response)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get project field '%s': %w", field, err)
+	}
+
+	for _, item := range response.Node.ProjectItems.Nodes {
+		if item.FieldValueByName != nil && item.FieldValueByName.Number != nil {
+			return *item.FieldValueByName.Number, true, nil
+		}
+	}
+	return 0, false, nil
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeProgress(t *testing.T) {
+	progress := computeProgress(testTree(), func(*IssueNode) float64 { return 1 })
+
+	// Leaves are #4 (closed), #5 (open) and #3 (closed)
+	assert.Equal(t, 2.0, progress.Done)
+	assert.Equal(t, 3.0, progress.Total)
+	assert.Equal(t, 66, progress.Percent)
+
+	assert.Len(t, progress.Children, 2)
+	assert.Equal(t, 50, progress.Children[0].Percent)
+	assert.Equal(t, 100, progress.Children[1].Percent)
+}
+
+func TestComputeProgressWeighted(t *testing.T) {
+	weights := map[int]float64{4: 5, 5: 3, 3: 0}
+	progress := computeProgress(testTree(), func(node *IssueNode) float64 { return weights[node.Number] })
+
+	assert.Equal(t, 5.0, progress.Done)
+	assert.Equal(t, 8.0, progress.Total)
+	assert.Equal(t, 62, progress.Percent)
+
+	// A branch without any weight has no percentage
+	assert.Equal(t, 0, progress.Children[1].Percent)
+}
+
+func TestComputeProgressSingleIssue(t *testing.T) {
+	progress := computeProgress(&IssueNode{Number: 1, State: "closed"}, func(*IssueNode) float64 { return 1 })
+	assert.Equal(t, 100, progress.Percent)
+	assert.Empty(t, progress.Children)
+}
+
+func TestLabelWeight(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels []string
+		prefix string
+		want   float64
+		found  bool
+	}{
+		{"prefix with space", []string{"bug", "points: 3"}, "points:", 3, true},
+		{"case insensitive", []string{"SP-5"}, "sp-", 5, true},
+		{"decimal", []string{"points:0.5"}, "points:", 0.5, true},
+		{"not a number", []string{"points: many"}, "points:", 0, false},
+		{"no label", []string{"bug"}, "points:", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := labelWeight(tt.labels, tt.prefix)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.found, found)
+		})
+	}
+}
+
+func TestProgressBar(t *testing.T) {
+	assert.Equal(t, "░░░░░░░░░░", progressBar(0, 10))
+	assert.Equal(t, "█████░░░░░", progressBar(50, 10))
+	assert.Equal(t, "██████████", progressBar(100, 10))
+}
+
+func TestFormatProgress(t *testing.T) {
+	tree := testTree()
+	tree.Title = "Epic"
+	tree.Children[0].Title = "Backend"
+	progress := computeProgress(tree, func(*IssueNode) float64 { return 1 })
+
+	tty := formatProgressTTY(progress, "issues")
+	assert.Contains(t, tty, "#1 - Epic")
+	assert.Contains(t, tty, "66%  (2 of 3 issues done)")
+	assert.Contains(t, tty, "Backend")
+
+	plain := formatProgressPlain(progress)
+	lines := strings.Split(strings.TrimSpace(plain), "\n")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, "2\topen\tBackend\t1\t2\t50", lines[0])
+	assert.Equal(t, "1\topen\tEpic\t2\t3\t66", lines[2])
+
+	output, err := formatProgressJSON(progress)
+	assert.NoError(t, err)
+	var parsed Progress
+	assert.NoError(t, json.Unmarshal([]byte(output), &parsed))
+	assert.Equal(t, 66, parsed.Percent)
+	assert.Len(t, parsed.Children[0].Children, 2)
+}
@@ -2,15 +2,18 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/term"
 	"github.com/spf13/cobra"
 )
 
 var (
 	removeRepoFlag  string
 	removeForceFlag bool
+	removeJSONFlag  bool
 )
 
 var removeCmd = &cobra.Command{
@@ -33,7 +36,10 @@ Examples:
   gh sub-issue remove 123 456 --repo owner/repo
 
   # Skip confirmation prompt
-  gh sub-issue remove 123 456 --force`,
+  gh sub-issue remove 123 456 --force
+
+  # Machine-readable per-issue results
+  gh sub-issue remove 123 456 457 --force --json`,
 	Args: cobra.MinimumNArgs(2),
 	RunE: runRemove,
 }
@@ -42,6 +48,7 @@ func init() {
 	rootCmd.AddCommand(removeCmd)
 	removeCmd.Flags().StringVarP(&removeRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
 	removeCmd.Flags().BoolVarP(&removeForceFlag, "force", "f", false, "Skip confirmation prompt")
+	removeCmd.Flags().BoolVar(&removeJSONFlag, "json", false, "Output per-issue results as JSON")
 }
 
 func runRemove(cmd *cobra.Command, args []string) error {
@@ -86,8 +93,8 @@ func runRemove(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create API client: %w", err)
 	}
 
-	// Get confirmation if not forced
-	if !removeForceFlag {
+	// Get confirmation if not forced (nothing is changed in dry-run mode)
+	if !removeForceFlag && !dryRunFlag {
 		var subNumbers []string
 		for _, ref := range subRefs {
 			subNumbers = append(subNumbers, fmt.Sprintf("#%d", ref.Number))
@@ -114,7 +121,7 @@ func runRemove(cmd *cobra.Command, args []string) error {
 	// Get parent issue node ID
 	fmt.Fprintf(cmd.OutOrStderr(), "Getting parent issue #%d from %s/%s...\n", 
 		parentRef.Number, parentRef.Owner, parentRef.Repo)
-	parentID, err := getIssueNodeID(client, parentRef.Owner, parentRef.Repo, parentRef.Number)
+	parent, err := getIssue(client, parentRef.Owner, parentRef.Repo, parentRef.Number)
 	if err != nil {
 		if strings.Contains(err.Error(), "Could not resolve") {
 			return fmt.Errorf("parent issue #%d not found in %s/%s", 
@@ -126,32 +133,55 @@ func runRemove(cmd *cobra.Command, args []string) error {
 	// Remove each sub-issue
 	var removedIssues []string
 	var errors []error
+	result := &MutationResult{Parent: newIssueResult(parentRef, parent)}
 	
 	for _, subRef := range subRefs {
 		// Get sub-issue node ID
 		fmt.Fprintf(cmd.OutOrStderr(), "Removing sub-issue #%d...\n", subRef.Number)
-		subID, err := getIssueNodeID(client, subRef.Owner, subRef.Repo, subRef.Number)
+		sub, err := getIssue(client, subRef.Owner, subRef.Repo, subRef.Number)
 		if err != nil {
 			if strings.Contains(err.Error(), "Could not resolve") {
 				err = fmt.Errorf("sub-issue #%d not found in %s/%s", 
 					subRef.Number, subRef.Owner, subRef.Repo)
 			}
 			errors = append(errors, err)
+			result.SubIssues = append(result.SubIssues, failedIssueResult(subRef, nil, err))
 			continue
 		}
 
 		// Execute GraphQL mutation to remove sub-issue
-		err = removeSubIssue(client, parentID, subID)
+		err = removeSubIssue(client, parent.ID, sub.ID)
 		if err != nil {
 			if strings.Contains(err.Error(), "not a sub-issue") {
 				err = fmt.Errorf("warning: #%d is not a sub-issue of #%d", 
 					subRef.Number, parentRef.Number)
 			}
 			errors = append(errors, err)
+			result.SubIssues = append(result.SubIssues, failedIssueResult(subRef, sub, err))
 			continue
 		}
 		
 		removedIssues = append(removedIssues, fmt.Sprintf("#%d", subRef.Number))
+		subResult := newIssueResult(subRef, sub)
+		subResult.Status = statusRemoved
+		result.SubIssues = append(result.SubIssues, subResult)
+	}
+
+	if dryRunFlag {
+		for _, err := range errors {
+			warnf("%v", err)
+		}
+		return writePlan(cmd.OutOrStdout(), removeJSONFlag || !term.IsTerminal(os.Stdout))
+	}
+
+	if removeJSONFlag {
+		if err := writeMutationResult(cmd.OutOrStdout(), result); err != nil {
+			return err
+		}
+		if len(removedIssues) == 0 {
+			return fmt.Errorf("failed to remove any sub-issues")
+		}
+		return nil
 	}
 
 	// Display results
@@ -219,7 +249,7 @@ func removeSubIssue(client *api.GraphQLClient, parentID, subIssueID string) erro
 		}
 	}
 
-	err := client.Do(mutation, variables, &result)
+	err := doMutation(client, "removeSubIssue", "Unlink sub-issue from parent issue", mutation, variables, &result)
 	if err != nil {
 		// Handle authentication errors
 		if strings.Contains(err.Error(), "authentication") || strings.Contains(err.Error(), "401") {
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Result statuses reported per sub-issue
+const (
+	statusAdded       = "added"
+	statusCreated     = "created"
+	statusRemoved     = "removed"
+	statusFailed      = "failed"
+	statusExists      = "exists"
+	statusSkipped     = "skipped"
+	statusClosed      = "closed"
+	statusReopened    = "reopened"
+	statusUnchanged   = "unchanged"
+	statusTransferred = "transferred"
+)
+
+// IssueResult describes an issue affected by a mutating command
+type IssueResult struct {
+	Number     int    `json:"number"`
+	Title      string `json:"title,omitempty"`
+	URL        string `json:"url,omitempty"`
+	ID         string `json:"id,omitempty"`
+	Repository string `json:"repository"`
+	Status     string `json:"status,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// MutationResult is the --json output of add, create and remove
+type MutationResult struct {
+	Parent    IssueResult   `json:"parent"`
+	SubIssues []IssueResult `json:"subIssues"`
+	Warnings  []string      `json:"warnings,omitempty"`
+}
+
+// newIssueResult builds an IssueResult from a reference and optional issue info
+func newIssueResult(ref *IssueReference, info *IssueInfo) IssueResult {
+	result := IssueResult{
+		Number:     ref.Number,
+		Repository: fmt.Sprintf("%s/%s", ref.Owner, ref.Repo),
+	}
+	if info != nil {
+		result.Number = info.Number
+		result.URL = info.URL
+		result.ID = info.ID
+	}
+	return result
+}
+
+// failedIssueResult builds an IssueResult for an issue that could not be processed
+func failedIssueResult(ref *IssueReference, info *IssueInfo, err error) IssueResult {
+	result := newIssueResult(ref, info)
+	result.Status = statusFailed
+	result.Error = err.Error()
+	return result
+}
+
+// writeMutationResult prints a MutationResult as JSON, including collected warnings
+func writeMutationResult(w io.Writer, result *MutationResult) error {
+	if result.SubIssues == nil {
+		result.SubIssues = []IssueResult{}
+	}
+	if len(plan.Warnings) > 0 {
+		result.Warnings = plan.Warnings
+	}
+
+	jsonBytes, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format JSON: %w", err)
+	}
+	fmt.Fprintln(w, string(jsonBytes))
+	return nil
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewIssueResult(t *testing.T) {
+	ref := &IssueReference{Owner: "owner", Repo: "repo", Number: 12}
+
+	result := newIssueResult(ref, nil)
+	assert.Equal(t, IssueResult{Number: 12, Repository: "owner/repo"}, result)
+
+	info := &IssueInfo{ID: "I_12", Number: 12, URL: "https://github.com/owner/repo/issues/12"}
+	result = newIssueResult(ref, info)
+	assert.Equal(t, "I_12", result.ID)
+	assert.Equal(t, "https://github.com/owner/repo/issues/12", result.URL)
+	assert.Empty(t, result.Status)
+}
+
+func TestFailedIssueResult(t *testing.T) {
+	ref := &IssueReference{Owner: "owner", Repo: "repo", Number: 7}
+
+	result := failedIssueResult(ref, nil, errors.New("sub-issue #7 not found in owner/repo"))
+	assert.Equal(t, statusFailed, result.Status)
+	assert.Equal(t, "sub-issue #7 not found in owner/repo", result.Error)
+	assert.Equal(t, "owner/repo", result.Repository)
+}
+
+func TestWriteMutationResult(t *testing.T) {
+	plan = newMutationPlan()
+	t.Cleanup(func() { plan = newMutationPlan() })
+
+	result := &MutationResult{
+		Parent: IssueResult{Number: 1, Repository: "owner/repo", ID: "I_1"},
+		SubIssues: []IssueResult{
+			{Number: 2, Repository: "owner/repo", ID: "I_2", Status: statusRemoved},
+			{Number: 3, Repository: "owner/repo", Status: statusFailed, Error: "not a sub-issue"},
+		},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, writeMutationResult(&buf, result))
+
+	var parsed map[string]interface{}
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &parsed))
+
+	parent := parsed["parent"].(map[string]interface{})
+	assert.Equal(t, float64(1), parent["number"])
+	assert.NotContains(t, parent, "status")
+
+	subIssues := parsed["subIssues"].([]interface{})
+	assert.Len(t, subIssues, 2)
+	assert.Equal(t, "removed", subIssues[0].(map[string]interface{})["status"])
+	assert.Equal(t, "not a sub-issue", subIssues[1].(map[string]interface{})["error"])
+	assert.NotContains(t, parsed, "warnings")
+}
+
+func TestWriteMutationResultEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	assert.NoError(t, writeMutationResult(&buf, &MutationResult{}))
+	assert.Contains(t, buf.String(), `"subIssues": []`)
+}
+
+func TestMutatingCommandsHaveJSONFlag(t *testing.T) {
+	for _, name := range []string{"add", "create", "remove", "convert-tasklist", "orphans", "scaffold", "clone", "transfer"} {
+		t.Run(name, func(t *testing.T) {
+			cmd, _, err := rootCmd.Find([]string{name})
+			assert.NoError(t, err)
+			flag := cmd.Flags().Lookup("json")
+			if assert.NotNil(t, flag) {
+				assert.Equal(t, "bool", flag.Value.Type())
+			}
+		})
+	}
+}
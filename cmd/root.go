@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -9,6 +10,8 @@ import (
 
 var Version = "dev"
 
+var dryRunFlag bool
+
 var rootCmd = &cobra.Command{
 	Use:   "gh-sub-issue",
 	Short: "GitHub CLI extension for managing sub-issues",
@@ -21,10 +24,34 @@ This extension allows you to:
 	Version: Version,
 }
 
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&dryRunFlag, "dry-run", false, "Resolve everything and print the mutations that would be sent without changing anything")
+}
+
+// ExitError makes the process exit with a specific code
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return ""
+	}
+	return e.Err.Error()
+}
+
 func Execute() int {
 	// Add subcommands here (will be added in next tasks)
 	
 	if err := rootCmd.Execute(); err != nil {
+		var exitErr *ExitError
+		if errors.As(err, &exitErr) {
+			if exitErr.Err != nil {
+				fmt.Fprintln(os.Stderr, exitErr.Err)
+			}
+			return exitErr.Code
+		}
 		fmt.Fprintln(os.Stderr, err)
 		return 1
 	}
@@ -0,0 +1,359 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rootsRepoFlag  string
+	rootsOrgFlag   string
+	rootsStateFlag string
+	rootsSortFlag  string
+	rootsLimitFlag int
+	rootsJSONFlag  string
+)
+
+// rootsJSONFields are the fields accepted by roots --json
+var rootsJSONFields = []string{"closedCount", "depth", "number", "openCount", "percent", "repository", "state", "title", "total", "updatedAt", "url"}
+
+var rootsCmd = &cobra.Command{
+	Use:   "roots",
+	Short: "List top-level issues that have sub-issues",
+	Long: `List the issues that have sub-issues but no parent, with the number of open and
+closed sub-issues, completion, the depth of the hierarchy and the last activity
+anywhere in it.
+
+Sorting by updated or depth fetches every hierarchy before --limit is applied, so
+it is slower on large repositories. Only the hierarchies of the issues shown are
+fetched otherwise.
+
+Examples:
+  # Top-level issues of the current repository, most recently active first
+  gh sub-issue roots
+
+  # Every hierarchy of an organization, least complete first
+  gh sub-issue roots --org my-org --sort progress
+
+  # JSON output with selected fields
+  gh sub-issue roots --repo owner/repo --json number,title,percent,depth`,
+	Args: cobra.NoArgs,
+	RunE: runRoots,
+}
+
+func init() {
+	rootCmd.AddCommand(rootsCmd)
+	rootsCmd.Flags().StringVarP(&rootsRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+	rootsCmd.Flags().StringVar(&rootsOrgFlag, "org", "", "List top-level issues across an organization")
+	rootsCmd.Flags().StringVarP(&rootsStateFlag, "state", "s", "open", "Filter by state: {open|closed|all}")
+	rootsCmd.Flags().StringVar(&rootsSortFlag, "sort", "updated", "Sort by: {updated|number|progress|depth|total}")
+	rootsCmd.Flags().IntVarP(&rootsLimitFlag, "limit", "L", 30, "Maximum number of issues to display")
+	rootsCmd.Flags().StringVar(&rootsJSONFlag, "json", "", "Output JSON with the specified fields")
+	rootsCmd.MarkFlagsMutuallyExclusive("repo", "org")
+}
+
+// RootSummary describes a top-level issue and its hierarchy
+type RootSummary struct {
+	Number      int    `json:"number"`
+	Title       string `json:"title"`
+	State       string `json:"state"`
+	URL         string `json:"url"`
+	Repository  string `json:"repository"`
+	Total       int    `json:"total"`
+	OpenCount   int    `json:"openCount"`
+	ClosedCount int    `json:"closedCount"`
+	Percent     int    `json:"percent"`
+	Depth       int    `json:"depth"`
+	UpdatedAt   string `json:"updatedAt"`
+}
+
+// summarizeRoot builds the summary of a top-level issue from the sub-issue counts
+// returned when listing it. Depth and activity are filled in by addTree.
+func summarizeRoot(issue RepoIssue) RootSummary {
+	summary := RootSummary{
+		Number:      issue.Number,
+		Title:       issue.Title,
+		State:       issue.State,
+		URL:         issue.URL,
+		Repository:  issue.Repository,
+		Total:       issue.Total,
+		ClosedCount: issue.Completed,
+		UpdatedAt:   issue.UpdatedAt,
+	}
+	summary.OpenCount = summary.Total - summary.ClosedCount
+	if summary.Total > 0 {
+		summary.Percent = summary.ClosedCount * 100 / summary.Total
+	}
+	return summary
+}
+
+// addTree sets the depth of the hierarchy and its last activity from the issue's tree
+func (summary *RootSummary) addTree(tree *IssueNode) {
+	tree.Walk(func(node *IssueNode, depth int) {
+		if depth+1 > summary.Depth {
+			summary.Depth = depth + 1
+		}
+		// Timestamps are RFC 3339 in UTC, so they sort as strings
+		if node.UpdatedAt > summary.UpdatedAt {
+			summary.UpdatedAt = node.UpdatedAt
+		}
+	})
+}
+
+// sortRoots sorts summaries by the given key: most recent, least complete, deepest
+// and largest first, and by ascending number
+func sortRoots(roots []RootSummary, key string) error {
+	var less func(a, b RootSummary) bool
+	switch key {
+	case "updated":
+		less = func(a, b RootSummary) bool { return a.UpdatedAt > b.UpdatedAt }
+	case "number":
+		less = func(a, b RootSummary) bool { return a.Number < b.Number }
+	case "progress":
+		less = func(a, b RootSummary) bool { return a.Percent < b.Percent }
+	case "depth":
+		less = func(a, b RootSummary) bool { return a.Depth > b.Depth }
+	case "total":
+		less = func(a, b RootSummary) bool { return a.Total > b.Total }
+	default:
+		return fmt.Errorf("invalid sort key: %s (expected updated, number, progress, depth or total)", key)
+	}
+
+	sort.SliceStable(roots, func(i, j int) bool { return less(roots[i], roots[j]) })
+	return nil
+}
+
+// rootReference returns "#N" for issues in the current repository and "OWNER/REPO#N" otherwise
+func rootReference(root RootSummary, repository string) string {
+	if strings.EqualFold(root.Repository, repository) {
+		return fmt.Sprintf("#%d", root.Number)
+	}
+	return fmt.Sprintf("%s#%d", root.Repository, root.Number)
+}
+
+// formatRootsTTY formats top-level issues for the terminal
+func formatRootsTTY(roots []RootSummary, repository string) string {
+	var output strings.Builder
+
+	if len(roots) == 0 {
+		output.WriteString("No top-level issues with sub-issues found.\n")
+		return output.String()
+	}
+
+	output.WriteString(fmt.Sprintf("\nTOP-LEVEL ISSUES (%d)\n", len(roots)))
+	output.WriteString("─────────────────────────────\n")
+
+	for _, root := range roots {
+		icon := "🔵"
+		if root.State == "closed" {
+			icon = "✅"
+		}
+		output.WriteString(fmt.Sprintf("%s %-6s %-40s %s %3d%%  %d/%d closed  depth %d  %s\n",
+			icon, rootReference(root, repository), truncate(root.Title, 40),
+			progressBar(root.Percent, 10), root.Percent,
+			root.ClosedCount, root.Total, root.Depth, formatActivity(root.UpdatedAt)))
+	}
+
+	return output.String()
+}
+
+// formatRootsPlain formats top-level issues as tab-separated text
+func formatRootsPlain(roots []RootSummary, repository string) string {
+	var output strings.Builder
+
+	for _, root := range roots {
+		output.WriteString(fmt.Sprintf("%s\t%s\t%s\t%d\t%d\t%d\t%d\t%d\t%s\n",
+			rootReference(root, repository), root.State, root.Title,
+			root.Total, root.OpenCount, root.ClosedCount, root.Percent, root.Depth, root.UpdatedAt))
+	}
+
+	return output.String()
+}
+
+// formatActivity shortens an RFC 3339 timestamp to its date
+func formatActivity(updatedAt string) string {
+	if len(updatedAt) >= 10 {
+		return updatedAt[:10]
+	}
+	return updatedAt
+}
+
+// parseRootsJSONFields splits the --json value into fields and validates them
+func parseRootsJSONFields(value string) ([]string, error) {
+	fields := strings.Split(value, ",")
+	for i, field := range fields {
+		fields[i] = strings.TrimSpace(field)
+		valid := false
+		for _, f := range rootsJSONFields {
+			if fields[i] == f {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("invalid field: %s. Valid fields are: %s", fields[i], strings.Join(rootsJSONFields, ", "))
+		}
+	}
+	return fields, nil
+}
+
+// formatRootsJSON formats top-level issues as JSON with the selected fields
+func formatRootsJSON(roots []RootSummary, fields []string) (string, error) {
+	output := []map[string]interface{}{}
+	for _, root := range roots {
+		values := map[string]interface{}{
+			"closedCount": root.ClosedCount,
+			"depth":       root.Depth,
+			"number":      root.Number,
+			"openCount":   root.OpenCount,
+			"percent":     root.Percent,
+			"repository":  root.Repository,
+			"state":       root.State,
+			"title":       root.Title,
+			"total":       root.Total,
+			"updatedAt":   root.UpdatedAt,
+			"url":         root.URL,
+		}
+		item := make(map[string]interface{})
+		for _, field := range fields {
+			item[field] = values[field]
+		}
+		output = append(output, item)
+	}
+
+	jsonBytes, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(jsonBytes), nil
+}
+
+func runRoots(cmd *cobra.Command, args []string) error {
+	switch rootsStateFlag {
+	case "open", "closed", "all":
+	default:
+		return fmt.Errorf("invalid state: %s (expected open, closed or all)", rootsStateFlag)
+	}
+
+	// Validate the sort key before fetching anything
+	if err := sortRoots(nil, rootsSortFlag); err != nil {
+		return err
+	}
+
+	var fields []string
+	if cmd.Flags().Changed("json") {
+		if rootsJSONFlag == "" {
+			fmt.Fprintf(cmd.OutOrStderr(), "Specify one or more comma-separated fields for `--json`:\n  %s\n",
+				strings.Join(rootsJSONFields, "\n  "))
+			return fmt.Errorf("")
+		}
+		var err error
+		fields, err = parseRootsJSONFields(rootsJSONFlag)
+		if err != nil {
+			return err
+		}
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	var repository string
+	var issues []RepoIssue
+	if rootsOrgFlag != "" {
+		fmt.Fprintf(cmd.OutOrStderr(), "Finding top-level issues in %s...\n", rootsOrgFlag)
+		issues, err = searchOrgRootIssues(client, rootsOrgFlag, rootsStateFlag)
+		if err != nil {
+			return err
+		}
+	} else {
+		// Get default repository if not specified
+		var defaultOwner, defaultRepo string
+		if rootsRepoFlag != "" {
+			parts := strings.Split(rootsRepoFlag, "/")
+			if len(parts) != 2 {
+				return fmt.Errorf("invalid repository format: %s (expected OWNER/REPO)", rootsRepoFlag)
+			}
+			defaultOwner = parts[0]
+			defaultRepo = parts[1]
+		} else {
+			defaultOwner, defaultRepo, err = getDefaultRepo()
+			if err != nil {
+				return fmt.Errorf("no repository specified and could not determine from current directory: %w", err)
+			}
+		}
+		repository = defaultOwner + "/" + defaultRepo
+
+		fmt.Fprintf(cmd.OutOrStderr(), "Finding top-level issues in %s...\n", repository)
+		issues, err = listRootIssues(client, defaultOwner, defaultRepo, rootsStateFlag)
+		if err != nil {
+			return err
+		}
+	}
+
+	var roots []RootSummary
+	for _, issue := range issues {
+		roots = append(roots, summarizeRoot(issue))
+	}
+
+	// Depth and activity anywhere in the hierarchy need every tree before sorting and limiting
+	fetched := false
+	if rootsSortFlag == "depth" || rootsSortFlag == "updated" {
+		if err := addRootTrees(cmd, client, roots); err != nil {
+			return err
+		}
+		fetched = true
+	}
+
+	if err := sortRoots(roots, rootsSortFlag); err != nil {
+		return err
+	}
+	if rootsLimitFlag > 0 && len(roots) > rootsLimitFlag {
+		roots = roots[:rootsLimitFlag]
+	}
+
+	if !fetched {
+		if err := addRootTrees(cmd, client, roots); err != nil {
+			return err
+		}
+	}
+
+	// Format output
+	var output string
+	if cmd.Flags().Changed("json") {
+		output, err = formatRootsJSON(roots, fields)
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+		output += "\n"
+	} else if term.IsTerminal(os.Stdout) {
+		output = formatRootsTTY(roots, repository)
+	} else {
+		output = formatRootsPlain(roots, repository)
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), output)
+	return nil
+}
+
+// addRootTrees fetches the hierarchy of every top-level issue to fill in its depth and activity
+func addRootTrees(cmd *cobra.Command, client *api.GraphQLClient, roots []RootSummary) error {
+	for i := range roots {
+		owner, repo, _ := strings.Cut(roots[i].Repository, "/")
+		fmt.Fprintf(cmd.OutOrStderr(), "Getting sub-issue tree of %s#%d...\n", roots[i].Repository, roots[i].Number)
+		tree, err := getIssueTree(client, owner, repo, roots[i].Number, maxTreeDepth)
+		if err != nil {
+			return err
+		}
+		roots[i].addTree(tree)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeRoot(t *testing.T) {
+	issue := RepoIssue{IssueInfo: IssueInfo{Number: 1, Title: "Epic", State: "open"},
+		Repository: "owner/repo", UpdatedAt: "2025-01-01T00:00:00Z", Total: 4, Completed: 1}
+
+	summary := summarizeRoot(issue)
+	assert.Equal(t, 1, summary.Number)
+	assert.Equal(t, 4, summary.Total)
+	assert.Equal(t, 3, summary.OpenCount)
+	assert.Equal(t, 1, summary.ClosedCount)
+	assert.Equal(t, 25, summary.Percent)
+	assert.Equal(t, 0, summary.Depth)
+	assert.Equal(t, "2025-01-01T00:00:00Z", summary.UpdatedAt)
+
+	tree := testTree()
+	tree.Children[0].Children[1].UpdatedAt = "2025-02-03T10:00:00Z"
+	summary.addTree(tree)
+	assert.Equal(t, 4, summary.Total)
+	assert.Equal(t, 3, summary.Depth)
+	assert.Equal(t, "2025-02-03T10:00:00Z", summary.UpdatedAt)
+}
+
+func TestSortRoots(t *testing.T) {
+	roots := []RootSummary{
+		{Number: 1, Percent: 80, Depth: 2, Total: 3, UpdatedAt: "2025-01-02T00:00:00Z"},
+		{Number: 2, Percent: 10, Depth: 4, Total: 1, UpdatedAt: "2025-03-01T00:00:00Z"},
+		{Number: 3, Percent: 50, Depth: 3, Total: 9, UpdatedAt: "2025-02-01T00:00:00Z"},
+	}
+
+	tests := []struct {
+		key  string
+		want []int
+	}{
+		{"updated", []int{2, 3, 1}},
+		{"number", []int{1, 2, 3}},
+		{"progress", []int{2, 3, 1}},
+		{"depth", []int{2, 3, 1}},
+		{"total", []int{3, 1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			assert.NoError(t, sortRoots(roots, tt.key))
+			var got []int
+			for _, root := range roots {
+				got = append(got, root.Number)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	assert.Error(t, sortRoots(roots, "title"))
+}
+
+func TestFormatRootsPlain(t *testing.T) {
+	roots := []RootSummary{
+		{Number: 1, Title: "Epic", State: "open", Repository: "owner/repo", Total: 4, OpenCount: 1, ClosedCount: 3,
+			Percent: 75, Depth: 2, UpdatedAt: "2025-01-02T00:00:00Z"},
+		{Number: 9, Title: "Lib", State: "open", Repository: "other/lib", Total: 1, OpenCount: 1,
+			Depth: 2, UpdatedAt: "2025-01-01T00:00:00Z"},
+	}
+
+	expected := "#1\topen\tEpic\t4\t1\t3\t75\t2\t2025-01-02T00:00:00Z\n" +
+		"other/lib#9\topen\tLib\t1\t1\t0\t0\t2\t2025-01-01T00:00:00Z\n"
+	assert.Equal(t, expected, formatRootsPlain(roots, "owner/repo"))
+}
+
+func TestFormatRootsJSON(t *testing.T) {
+	roots := []RootSummary{{Number: 1, Title: "Epic", Percent: 75}}
+
+	fields, err := parseRootsJSONFields("number, percent")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"number", "percent"}, fields)
+
+	output, err := formatRootsJSON(roots, fields)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"number": 1, "percent": 75}]`, output)
+
+	_, err = parseRootsJSONFields("number,labels")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "invalid field: labels")
+	}
+}
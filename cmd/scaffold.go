@@ -0,0 +1,375 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// scaffoldRepoDir is where a repository keeps its scaffold templates
+const scaffoldRepoDir = ".github/sub-issue-templates"
+
+var (
+	scaffoldVarFlag    []string
+	scaffoldParentFlag string
+	scaffoldRepoFlag   string
+	scaffoldJSONFlag   bool
+)
+
+var scaffoldCmd = &cobra.Command{
+	Use:   "scaffold [<template>]",
+	Short: "Create an issue hierarchy from a template",
+	Long: `Create a whole issue hierarchy from a reusable template.
+
+Templates are YAML task lists, in the same format as 'create --from-file', named
+<template>.yaml in .github/sub-issue-templates/ of the repository or in
+~/.config/gh-sub-issue/templates/. The YAML is parsed first, then each title,
+body and metadata value is rendered as a Go template with the variables given by
+--var, so values are never read as YAML:
+
+  - title: "Release {{ .version }}"
+    labels: [release]
+    milestone: "v{{ .version }}"
+    children:
+      - title: "QA for {{ .version }}"
+        labels: [qa]
+      - title: "Changelog for {{ .version }}"
+
+Without a template name, the available templates are listed.
+
+Examples:
+  # List available templates
+  gh sub-issue scaffold
+
+  # Create the release breakdown as a new top-level hierarchy
+  gh sub-issue scaffold release --var version=2.3
+
+  # Create it under an existing issue
+  gh sub-issue scaffold release --var version=2.3 --parent 123
+
+  # Use a template file directly
+  gh sub-issue scaffold ./templates/sprint.yaml --var sprint=42`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runScaffold,
+}
+
+func init() {
+	rootCmd.AddCommand(scaffoldCmd)
+	scaffoldCmd.Flags().StringArrayVar(&scaffoldVarFlag, "var", nil, "Template variable in NAME=VALUE format (repeatable)")
+	scaffoldCmd.Flags().StringVarP(&scaffoldParentFlag, "parent", "p", "", "Create the hierarchy under this issue (number or URL)")
+	scaffoldCmd.Flags().StringVarP(&scaffoldRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+	scaffoldCmd.Flags().BoolVar(&scaffoldJSONFlag, "json", false, "Output results as JSON")
+}
+
+// ScaffoldResult is the --json output of scaffold
+type ScaffoldResult struct {
+	Template string        `json:"template"`
+	Parent   *IssueResult  `json:"parent,omitempty"`
+	Issues   []IssueResult `json:"issues"`
+}
+
+// scaffoldTemplate is a template found in one of the template directories
+type scaffoldTemplate struct {
+	Name string
+	Path string
+}
+
+// scaffoldDirs returns the template directories that exist, repository first
+func scaffoldDirs() []string {
+	var dirs []string
+	if dir := localRepoFile(scaffoldRepoDir); dir != "" {
+		dirs = append(dirs, dir)
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		dir := filepath.Join(home, ".config", "gh-sub-issue", "templates")
+		if _, err := os.Stat(dir); err == nil {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// listScaffoldTemplates lists the templates of the directories. A template in an earlier
+// directory hides one with the same name in a later directory.
+func listScaffoldTemplates(dirs []string) ([]scaffoldTemplate, error) {
+	seen := make(map[string]bool)
+	var templates []scaffoldTemplate
+
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read templates: %w", err)
+		}
+		for _, entry := range entries {
+			ext := strings.ToLower(filepath.Ext(entry.Name()))
+			if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+				continue
+			}
+			name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			templates = append(templates, scaffoldTemplate{Name: name, Path: filepath.Join(dir, entry.Name())})
+		}
+	}
+
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+// findScaffoldTemplate resolves a template name, or a path to a template file
+func findScaffoldTemplate(name string, dirs []string) (string, error) {
+	if strings.ContainsRune(name, filepath.Separator) || strings.ContainsRune(name, '/') {
+		if _, err := os.Stat(name); err != nil {
+			return "", fmt.Errorf("template file not found: %s", name)
+		}
+		return name, nil
+	}
+
+	templates, err := listScaffoldTemplates(dirs)
+	if err != nil {
+		return "", err
+	}
+	var names []string
+	for _, t := range templates {
+		if t.Name == name {
+			return t.Path, nil
+		}
+		names = append(names, t.Name)
+	}
+
+	if len(names) == 0 {
+		return "", fmt.Errorf("template '%s' not found: no templates in %s or ~/.config/gh-sub-issue/templates", name, scaffoldRepoDir)
+	}
+	return "", fmt.Errorf("template '%s' not found. Available templates: %s", name, strings.Join(names, ", "))
+}
+
+// parseScaffoldVars parses NAME=VALUE pairs
+func parseScaffoldVars(pairs []string) (map[string]string, error) {
+	vars := make(map[string]string)
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid variable: %s (expected NAME=VALUE)", pair)
+		}
+		vars[name] = value
+	}
+	return vars, nil
+}
+
+// renderScaffold parses a template as a task list and renders every string of its items
+// with the variables. Rendering after parsing keeps variable values from changing the
+// structure of the YAML.
+func renderScaffold(name, content string, vars map[string]string) ([]batchItem, error) {
+	var items []batchItem
+	if err := yaml.Unmarshal([]byte(content), &items); err != nil {
+		return nil, fmt.Errorf("invalid template %s: %w", name, err)
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("template %s contains no issues", name)
+	}
+
+	r := &scaffoldRenderer{name: name, vars: vars}
+	r.items(items)
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	if err := validateBatchItems(items, ""); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// scaffoldRenderer renders the strings of task list items in place, keeping the first error
+type scaffoldRenderer struct {
+	name string
+	vars map[string]string
+	err  error
+}
+
+// items renders the fields of the items and their children
+func (r *scaffoldRenderer) items(items []batchItem) {
+	for i := range items {
+		item := &items[i]
+		for _, field := range []*string{&item.Title, &item.Body, &item.Milestone, &item.Type} {
+			*field = r.render(*field)
+		}
+		for _, list := range []stringList{item.Labels, item.Assignees, item.Projects} {
+			for j := range list {
+				list[j] = r.render(list[j])
+			}
+		}
+		r.items(item.Children)
+	}
+}
+
+// render executes a single value as a template
+func (r *scaffoldRenderer) render(value string) string {
+	if r.err != nil || !strings.Contains(value, "{{") {
+		return value
+	}
+
+	tmpl, err := template.New(r.name).Option("missingkey=error").Parse(value)
+	if err != nil {
+		r.err = fmt.Errorf("invalid template %s: %w", r.name, err)
+		return value
+	}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, r.vars); err != nil {
+		r.err = fmt.Errorf("failed to render template %s (set variables with --var NAME=VALUE): %w", r.name, err)
+		return value
+	}
+	return rendered.String()
+}
+
+// formatScaffoldTemplates formats the available templates as a table
+func formatScaffoldTemplates(templates []scaffoldTemplate) string {
+	var output strings.Builder
+
+	w := tabwriter.NewWriter(&output, 0, 0, 2, ' ', 0)
+	for _, t := range templates {
+		fmt.Fprintf(w, "%s\t%s\n", t.Name, t.Path)
+	}
+	w.Flush()
+
+	return output.String()
+}
+
+func runScaffold(cmd *cobra.Command, args []string) error {
+	dirs := scaffoldDirs()
+
+	if len(args) == 0 {
+		templates, err := listScaffoldTemplates(dirs)
+		if err != nil {
+			return err
+		}
+		if len(templates) == 0 {
+			fmt.Fprintf(cmd.OutOrStderr(), "No templates found in %s or ~/.config/gh-sub-issue/templates.\n", scaffoldRepoDir)
+			return nil
+		}
+		fmt.Fprint(cmd.OutOrStdout(), formatScaffoldTemplates(templates))
+		return nil
+	}
+
+	vars, err := parseScaffoldVars(scaffoldVarFlag)
+	if err != nil {
+		return err
+	}
+
+	path, err := findScaffoldTemplate(args[0], dirs)
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read template: %w", err)
+	}
+	items, err := renderScaffold(args[0], string(content), vars)
+	if err != nil {
+		return err
+	}
+
+	// Get default repository if not specified
+	var defaultOwner, defaultRepo string
+	if scaffoldRepoFlag != "" {
+		parts := strings.Split(scaffoldRepoFlag, "/")
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid repository format: %s (expected OWNER/REPO)", scaffoldRepoFlag)
+		}
+		defaultOwner = parts[0]
+		defaultRepo = parts[1]
+	} else {
+		defaultOwner, defaultRepo, err = getDefaultRepo()
+		if err != nil {
+			return fmt.Errorf("no repository specified and could not determine from current directory: %w", err)
+		}
+	}
+
+	var parentRef *IssueReference
+	if scaffoldParentFlag != "" {
+		parentRef, err = parseIssueReference(scaffoldParentFlag, defaultOwner, defaultRepo)
+		if err != nil {
+			return fmt.Errorf("invalid parent issue: %w", err)
+		}
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	result := &ScaffoldResult{Template: args[0]}
+	parentID := ""
+	if parentRef != nil {
+		fmt.Fprintf(cmd.OutOrStderr(), "Getting parent issue #%d from %s/%s...\n",
+			parentRef.Number, parentRef.Owner, parentRef.Repo)
+		parent, err := getIssue(client, parentRef.Owner, parentRef.Repo, parentRef.Number)
+		if err != nil {
+			return err
+		}
+		parentID = parent.ID
+		parentResult := newIssueResult(parentRef, parent)
+		result.Parent = &parentResult
+	}
+
+	repoID, err := getRepositoryID(client, defaultOwner, defaultRepo)
+	if err != nil {
+		return err
+	}
+
+	creator := &batchCreator{
+		client:   client,
+		owner:    defaultOwner,
+		repo:     defaultRepo,
+		repoID:   repoID,
+		defaults: &newSubIssue{},
+		progress: cmd.OutOrStderr(),
+	}
+	createErr := creator.create(parentID, items, 0)
+	if createErr != nil && (dryRunFlag || len(creator.results) == 0) {
+		return createErr
+	}
+
+	if dryRunFlag {
+		return writePlan(cmd.OutOrStdout(), scaffoldJSONFlag || !term.IsTerminal(os.Stdout))
+	}
+
+	if scaffoldJSONFlag {
+		result.Issues = []IssueResult{}
+		for _, r := range creator.results {
+			result.Issues = append(result.Issues, r.IssueResult)
+		}
+		jsonBytes, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(jsonBytes))
+	} else {
+		fmt.Fprint(cmd.OutOrStdout(), formatBatchSummary(creator.results))
+	}
+
+	if createErr != nil {
+		return createErr
+	}
+
+	counts := countBatchResults(creator.results)
+	if failed := counts[statusFailed] + counts[statusSkipped]; failed > 0 {
+		return fmt.Errorf("%d of %d issues could not be created", failed, len(creator.results))
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testScaffold = `- title: "Release {{ .version }}"
+  labels: [release]
+  milestone: "v{{ .version }}"
+  children:
+    - title: "QA for {{ .version }}"
+      labels: [qa]
+    - Changelog
+`
+
+func TestRenderScaffold(t *testing.T) {
+	items, err := renderScaffold("release", testScaffold, map[string]string{"version": "2.3"})
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.Equal(t, "Release 2.3", items[0].Title)
+	assert.Equal(t, "v2.3", items[0].Milestone)
+	assert.Equal(t, stringList{"release"}, items[0].Labels)
+	assert.Equal(t, "QA for 2.3", items[0].Children[0].Title)
+	assert.Equal(t, "Changelog", items[0].Children[1].Title)
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"missing variable", testScaffold, "set variables with --var"},
+		{"bad template", "- title: \"{{ .version\"", "invalid template release"},
+		{"bad yaml", "- title: [", "invalid template release"},
+		{"empty title", "- title: \"{{ .empty }}\"\n  body: x", "item 1 has no title"},
+		{"no title", "- body: x", "item 1 has no title"},
+		{"empty", "", "contains no issues"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := renderScaffold("release", tt.content, map[string]string{"empty": " "})
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRenderScaffoldValuesStayValues(t *testing.T) {
+	// Quotes, colons, comments and newlines in values must not change the YAML
+	vars := map[string]string{"version": "2.3\" labels: [hacked]: # x\n- title: injected"}
+	items, err := renderScaffold("release", testScaffold, vars)
+	assert.NoError(t, err)
+	if assert.Len(t, items, 1) {
+		assert.Equal(t, "Release "+vars["version"], items[0].Title)
+		assert.Equal(t, stringList{"release"}, items[0].Labels)
+		assert.Len(t, items[0].Children, 2)
+	}
+}
+
+func TestParseScaffoldVars(t *testing.T) {
+	vars, err := parseScaffoldVars([]string{"version=2.3", "date=2024-01-01", "note=a=b", "empty="})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"version": "2.3", "date": "2024-01-01", "note": "a=b", "empty": ""}, vars)
+
+	for _, pair := range []string{"version", "=2.3"} {
+		_, err := parseScaffoldVars([]string{pair})
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "expected NAME=VALUE")
+		}
+	}
+}
+
+func TestScaffoldTemplates(t *testing.T) {
+	repoDir := t.TempDir()
+	userDir := t.TempDir()
+	write := func(dir, name string) {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("- Task\n"), 0o644))
+	}
+	write(repoDir, "release.yaml")
+	write(repoDir, "README.md")
+	write(userDir, "release.yml")
+	write(userDir, "sprint.yml")
+
+	templates, err := listScaffoldTemplates([]string{repoDir, userDir})
+	assert.NoError(t, err)
+	assert.Equal(t, []scaffoldTemplate{
+		{Name: "release", Path: filepath.Join(repoDir, "release.yaml")},
+		{Name: "sprint", Path: filepath.Join(userDir, "sprint.yml")},
+	}, templates)
+
+	path, err := findScaffoldTemplate("sprint", []string{repoDir, userDir})
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(userDir, "sprint.yml"), path)
+
+	_, err = findScaffoldTemplate("hotfix", []string{repoDir, userDir})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "Available templates: release, sprint")
+	}
+
+	_, err = findScaffoldTemplate("hotfix", nil)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "no templates in .github/sub-issue-templates")
+	}
+
+	path, err = findScaffoldTemplate(filepath.Join(repoDir, "release.yaml"), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(repoDir, "release.yaml"), path)
+}
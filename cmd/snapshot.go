@@ -0,0 +1,470 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/spf13/cobra"
+)
+
+// snapshotVersion is the version of the snapshot format written by snapshot
+const snapshotVersion = 1
+
+// Restore change actions
+const (
+	restoreLink    = "link"
+	restoreReorder = "reorder"
+	restoreClose   = "close"
+	restoreReopen  = "reopen"
+)
+
+var (
+	snapshotOutputFlag string
+	snapshotRepoFlag   string
+	restoreForceFlag   bool
+)
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot <issue>",
+	Short: "Save the structure of a hierarchy to a file",
+	Long: `Save an issue and all of its sub-issues to a JSON file: node IDs, numbers,
+titles, states and the order of sub-issues. Take a snapshot before a large remove
+or move, and use restore to go back to it.
+
+Examples:
+  gh sub-issue snapshot 123 -o epic.json
+
+  # Print to stdout
+  gh sub-issue snapshot 123 --repo owner/repo`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSnapshot,
+}
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <snapshot-file>",
+	Short: "Restore the structure of a hierarchy from a snapshot",
+	Long: `Re-link sub-issues, restore their order and reopen or close issues so that the
+hierarchy matches a snapshot taken with 'gh sub-issue snapshot'. The changes are
+shown and confirmed before anything is changed.
+
+Sub-issues added after the snapshot are left in place and issues that were deleted
+or can no longer be accessed are skipped. Issues are found by node ID, so a
+snapshot stays valid after issues are transferred.
+
+Examples:
+  gh sub-issue restore epic.json
+
+  # Only show the changes
+  gh sub-issue restore epic.json --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRestore,
+}
+
+func init() {
+	rootCmd.AddCommand(snapshotCmd)
+	rootCmd.AddCommand(restoreCmd)
+	snapshotCmd.Flags().StringVarP(&snapshotOutputFlag, "output", "o", "", "Write the snapshot to a file instead of stdout")
+	snapshotCmd.Flags().StringVarP(&snapshotRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+	restoreCmd.Flags().BoolVarP(&restoreForceFlag, "force", "f", false, "Skip confirmation prompt")
+}
+
+// Snapshot is a saved issue hierarchy
+type Snapshot struct {
+	Version   int           `json:"version"`
+	CreatedAt string        `json:"createdAt"`
+	Root      SnapshotIssue `json:"root"`
+}
+
+// SnapshotIssue is an issue of a snapshot with its sub-issues in order
+type SnapshotIssue struct {
+	ID          string          `json:"id"`
+	Number      int             `json:"number"`
+	Title       string          `json:"title"`
+	URL         string          `json:"url"`
+	Repository  string          `json:"repository"`
+	State       string          `json:"state"`
+	StateReason string          `json:"stateReason,omitempty"`
+	Children    []SnapshotIssue `json:"children"`
+}
+
+// newSnapshot builds a snapshot of a tree
+func newSnapshot(tree *IssueNode, createdAt time.Time) *Snapshot {
+	var convert func(node *IssueNode) SnapshotIssue
+	convert = func(node *IssueNode) SnapshotIssue {
+		issue := SnapshotIssue{
+			ID:          node.ID,
+			Number:      node.Number,
+			Title:       node.Title,
+			URL:         node.URL,
+			Repository:  node.Repository,
+			State:       node.State,
+			StateReason: node.StateReason,
+			Children:    []SnapshotIssue{},
+		}
+		for _, child := range node.Children {
+			issue.Children = append(issue.Children, convert(child))
+		}
+		return issue
+	}
+
+	return &Snapshot{
+		Version:   snapshotVersion,
+		CreatedAt: createdAt.UTC().Format(time.RFC3339),
+		Root:      convert(tree),
+	}
+}
+
+// parseSnapshot reads a snapshot of any supported version
+func parseSnapshot(data []byte) (*Snapshot, error) {
+	var header struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("invalid snapshot: %w", err)
+	}
+
+	// Versions before the current one are upgraded here when the format changes
+	switch {
+	case header.Version == 0:
+		return nil, fmt.Errorf("invalid snapshot: missing version")
+	case header.Version > snapshotVersion:
+		return nil, fmt.Errorf("snapshot version %d is newer than this version of gh sub-issue supports (%d); please upgrade", header.Version, snapshotVersion)
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("invalid snapshot: %w", err)
+	}
+	if snapshot.Root.ID == "" {
+		return nil, fmt.Errorf("invalid snapshot: missing root issue")
+	}
+	return &snapshot, nil
+}
+
+// walk calls fn for every issue of the snapshot, parents before children
+func (s *SnapshotIssue) walk(fn func(issue *SnapshotIssue)) {
+	fn(s)
+	for i := range s.Children {
+		s.Children[i].walk(fn)
+	}
+}
+
+// reference returns "#N" for issues in the repository and "OWNER/REPO#N" otherwise
+func (s *SnapshotIssue) reference(repository string) string {
+	if strings.EqualFold(s.Repository, repository) {
+		return fmt.Sprintf("#%d", s.Number)
+	}
+	return fmt.Sprintf("%s#%d", s.Repository, s.Number)
+}
+
+// currentIssue is the current state of an issue of a snapshot
+type currentIssue struct {
+	State    string
+	Children []IssueInfo
+}
+
+// restoreChange is a change needed to make a hierarchy match its snapshot
+type restoreChange struct {
+	Action string
+	Issue  *SnapshotIssue
+	Parent *SnapshotIssue
+	// Order holds the sub-issues of Issue in their restored order, for reorders
+	Order []*SnapshotIssue
+}
+
+// diffSnapshot computes the changes that make the current issues match the snapshot.
+// Issues missing from current no longer exist and are skipped.
+func diffSnapshot(snapshot *Snapshot, current map[string]*currentIssue) []restoreChange {
+	var changes []restoreChange
+
+	snapshot.Root.walk(func(issue *SnapshotIssue) {
+		cur, ok := current[issue.ID]
+		if !ok {
+			return
+		}
+
+		if issue.State != cur.State {
+			action := restoreReopen
+			if issue.State == "closed" {
+				action = restoreClose
+			}
+			changes = append(changes, restoreChange{Action: action, Issue: issue})
+		}
+
+		var expected []string
+		var order []*SnapshotIssue
+		byID := make(map[string]*SnapshotIssue)
+		for i := range issue.Children {
+			child := &issue.Children[i]
+			if _, ok := current[child.ID]; !ok {
+				continue
+			}
+			expected = append(expected, child.ID)
+			order = append(order, child)
+			byID[child.ID] = child
+		}
+		missing, reorder := childRepairs(expected, cur.Children)
+		for _, id := range missing {
+			changes = append(changes, restoreChange{Action: restoreLink, Issue: byID[id], Parent: issue})
+		}
+		if reorder {
+			changes = append(changes, restoreChange{Action: restoreReorder, Issue: issue, Order: order})
+		}
+	})
+
+	return changes
+}
+
+// formatRestoreChanges formats the changes of a restore
+func formatRestoreChanges(changes []restoreChange, repository string) string {
+	var output strings.Builder
+
+	if len(changes) == 0 {
+		output.WriteString("No changes. The hierarchy matches the snapshot.\n")
+		return output.String()
+	}
+
+	counts := make(map[string]int)
+	for _, change := range changes {
+		counts[change.Action]++
+		ref := change.Issue.reference(repository)
+
+		switch change.Action {
+		case restoreLink:
+			output.WriteString(fmt.Sprintf("  → link     %s under %s\n", ref, change.Parent.reference(repository)))
+		case restoreReorder:
+			var order []string
+			for _, child := range change.Order {
+				order = append(order, child.reference(repository))
+			}
+			output.WriteString(fmt.Sprintf("  ↕ reorder  sub-issues of %s: %s\n", ref, strings.Join(order, ", ")))
+		case restoreClose:
+			line := fmt.Sprintf("  ~ close    %s", ref)
+			if change.Issue.StateReason == "not_planned" {
+				line += " as not planned"
+			}
+			output.WriteString(line + "\n")
+		case restoreReopen:
+			output.WriteString(fmt.Sprintf("  ~ reopen   %s\n", ref))
+		}
+	}
+
+	output.WriteString(fmt.Sprintf("\nRestore: %d to link, %d to reorder, %d to close, %d to reopen.\n",
+		counts[restoreLink], counts[restoreReorder], counts[restoreClose], counts[restoreReopen]))
+	return output.String()
+}
+
+// getCurrentIssue gets the state and sub-issues of an issue by node ID, or nil when it no
+// longer exists
+func getCurrentIssue(client *api.GraphQLClient, issueID string) (*currentIssue, error) {
+	query := `
+		query($id: ID!) {
+			node(id: $id) {
+				... on Issue {
+					state
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"id": issueID,
+	}
+
+	var response struct {
+		Node *struct {
+			State string `json:"state"`
+		} `json:"node"`
+	}
+
+	err := client.Do(query, variables, &response)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue: %w", err)
+	}
+	if response.Node == nil || response.Node.State == "" {
+		return nil, nil
+	}
+
+	// All sub-issues are needed, or those past the first page would be relinked
+	children, err := getChildIssues(client, issueID)
+	if err != nil {
+		return nil, err
+	}
+	return &currentIssue{
+		State:    strings.ToLower(response.Node.State),
+		Children: children,
+	}, nil
+}
+
+// isNotFound reports whether err only holds NOT_FOUND GraphQL errors, which GitHub returns
+// for the IDs of deleted or inaccessible issues
+func isNotFound(err error) bool {
+	var gqlErr *api.GraphQLError
+	if !errors.As(err, &gqlErr) || len(gqlErr.Errors) == 0 {
+		return false
+	}
+	for _, item := range gqlErr.Errors {
+		if item.Type != "NOT_FOUND" {
+			return false
+		}
+	}
+	return true
+}
+
+// applyRestoreChange makes one change of a restore
+func applyRestoreChange(client *api.GraphQLClient, change restoreChange) error {
+	switch change.Action {
+	case restoreLink:
+		return moveSubIssue(client, change.Parent.ID, change.Issue.ID)
+	case restoreReorder:
+		children := change.Order
+		for i := 1; i < len(children); i++ {
+			if err := reprioritizeSubIssue(client, change.Issue.ID, children[i].ID, children[i-1].ID); err != nil {
+				return err
+			}
+		}
+		return nil
+	case restoreClose:
+		reason := "COMPLETED"
+		if change.Issue.StateReason == "not_planned" {
+			reason = "NOT_PLANNED"
+		}
+		return closeIssue(client, change.Issue.ID, reason)
+	case restoreReopen:
+		return reopenIssue(client, change.Issue.ID)
+	}
+	return fmt.Errorf("unknown change %s", change.Action)
+}
+
+func runSnapshot(cmd *cobra.Command, args []string) error {
+	// Get default repository if not specified
+	var defaultOwner, defaultRepo string
+	if snapshotRepoFlag != "" {
+		parts := strings.Split(snapshotRepoFlag, "/")
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid repository format: %s (expected OWNER/REPO)", snapshotRepoFlag)
+		}
+		defaultOwner = parts[0]
+		defaultRepo = parts[1]
+	} else {
+		var err error
+		defaultOwner, defaultRepo, err = getDefaultRepo()
+		if err != nil {
+			return fmt.Errorf("no repository specified and could not determine from current directory: %w", err)
+		}
+	}
+
+	ref, err := parseIssueReference(args[0], defaultOwner, defaultRepo)
+	if err != nil {
+		return fmt.Errorf("invalid issue: %w", err)
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStderr(), "Getting sub-issue tree of #%d from %s/%s...\n", ref.Number, ref.Owner, ref.Repo)
+	tree, err := getIssueTree(client, ref.Owner, ref.Repo, ref.Number, maxTreeDepth)
+	if err != nil {
+		return err
+	}
+
+	jsonBytes, err := json.MarshalIndent(newSnapshot(tree, time.Now()), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to format JSON: %w", err)
+	}
+
+	if snapshotOutputFlag == "" {
+		fmt.Fprintln(cmd.OutOrStdout(), string(jsonBytes))
+		return nil
+	}
+	if err := os.WriteFile(snapshotOutputFlag, append(jsonBytes, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", snapshotOutputFlag, err)
+	}
+	count := 0
+	tree.Walk(func(node *IssueNode, depth int) { count++ })
+	fmt.Fprintf(cmd.OutOrStderr(), "✓ Saved %d issue(s) of #%d to %s\n", count, ref.Number, snapshotOutputFlag)
+	return nil
+}
+
+func runRestore(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	snapshot, err := parseSnapshot(data)
+	if err != nil {
+		return err
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	repository := snapshot.Root.Repository
+	fmt.Fprintf(cmd.OutOrStderr(), "Comparing %s with the snapshot of %s...\n",
+		snapshot.Root.reference(""), snapshot.CreatedAt)
+
+	current := make(map[string]*currentIssue)
+	var missing []string
+	var fetchErr error
+	snapshot.Root.walk(func(issue *SnapshotIssue) {
+		if fetchErr != nil {
+			return
+		}
+		var cur *currentIssue
+		cur, fetchErr = getCurrentIssue(client, issue.ID)
+		if cur == nil {
+			missing = append(missing, issue.reference(repository))
+			return
+		}
+		current[issue.ID] = cur
+	})
+	if fetchErr != nil {
+		return fetchErr
+	}
+	for _, ref := range missing {
+		warnf("issue %s no longer exists and is skipped", ref)
+	}
+
+	changes := diffSnapshot(snapshot, current)
+	fmt.Fprint(cmd.OutOrStderr(), formatRestoreChanges(changes, repository))
+	if len(changes) == 0 {
+		return nil
+	}
+
+	// Confirm unless forced or only planning
+	if !restoreForceFlag && !dryRunFlag {
+		fmt.Fprint(cmd.OutOrStderr(), "\nRestore these changes? (y/N): ")
+		var response string
+		fmt.Scanln(&response)
+		if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
+			fmt.Fprintln(cmd.OutOrStderr(), "Restore cancelled")
+			return nil
+		}
+	}
+
+	for i, change := range changes {
+		if err := applyRestoreChange(client, change); err != nil {
+			return fmt.Errorf("restore stopped after %d of %d changes: %s %s: %w",
+				i, len(changes), change.Action, change.Issue.reference(repository), err)
+		}
+	}
+
+	if dryRunFlag {
+		return writePlan(cmd.OutOrStdout(), !term.IsTerminal(os.Stdout))
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ Restored %s to the snapshot of %s (%d changes)\n",
+		snapshot.Root.reference(""), snapshot.CreatedAt, len(changes))
+	return nil
+}
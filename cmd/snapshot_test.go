@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+// testSnapshot is a snapshot of testTree with node IDs
+func testSnapshot() *Snapshot {
+	tree := testTree()
+	tree.Walk(func(node *IssueNode, depth int) {
+		node.ID = string(rune('A' + node.Number - 1))
+	})
+	tree.Children[1].StateReason = "not_planned"
+	return newSnapshot(tree, time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+}
+
+func TestNewSnapshot(t *testing.T) {
+	snapshot := testSnapshot()
+	assert.Equal(t, snapshotVersion, snapshot.Version)
+	assert.Equal(t, "2024-05-01T12:00:00Z", snapshot.CreatedAt)
+	assert.Equal(t, "A", snapshot.Root.ID)
+	assert.Equal(t, []int{2, 3}, []int{snapshot.Root.Children[0].Number, snapshot.Root.Children[1].Number})
+	assert.Equal(t, "other/lib", snapshot.Root.Children[0].Children[1].Repository)
+	assert.Equal(t, []SnapshotIssue{}, snapshot.Root.Children[1].Children)
+}
+
+func TestParseSnapshot(t *testing.T) {
+	data, err := json.Marshal(testSnapshot())
+	assert.NoError(t, err)
+
+	snapshot, err := parseSnapshot(data)
+	assert.NoError(t, err)
+	assert.Equal(t, testSnapshot(), snapshot)
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"not json", "{", "invalid snapshot"},
+		{"no version", `{"root": {"id": "A"}}`, "missing version"},
+		{"newer version", `{"version": 99, "root": {"id": "A"}}`, "snapshot version 99 is newer"},
+		{"no root", `{"version": 1}`, "missing root issue"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseSnapshot([]byte(tt.data))
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+// currentFromSnapshot returns the current state of issues that still match the snapshot
+func currentFromSnapshot(snapshot *Snapshot) map[string]*currentIssue {
+	current := make(map[string]*currentIssue)
+	snapshot.Root.walk(func(issue *SnapshotIssue) {
+		cur := &currentIssue{State: issue.State}
+		for _, child := range issue.Children {
+			cur.Children = append(cur.Children, IssueInfo{ID: child.ID, Number: child.Number})
+		}
+		current[issue.ID] = cur
+	})
+	return current
+}
+
+func TestDiffSnapshot(t *testing.T) {
+	summarize := func(changes []restoreChange) []string {
+		var result []string
+		for _, change := range changes {
+			result = append(result, change.Action+" "+change.Issue.ID)
+		}
+		return result
+	}
+
+	snapshot := testSnapshot()
+	assert.Empty(t, diffSnapshot(snapshot, currentFromSnapshot(snapshot)))
+
+	// #3 was removed from #1, #4 and #5 were swapped and #2 was closed
+	current := currentFromSnapshot(snapshot)
+	current["A"].Children = current["A"].Children[:1]
+	current["B"].Children = []IssueInfo{{ID: "E"}, {ID: "D"}}
+	current["B"].State = "closed"
+	changes := diffSnapshot(snapshot, current)
+	assert.Equal(t, []string{"link C", "reopen B", "reorder B"}, summarize(changes))
+	assert.Equal(t, "A", changes[0].Parent.ID)
+
+	// Deleted issues are skipped
+	current = currentFromSnapshot(snapshot)
+	delete(current, "D")
+	current["B"].Children = []IssueInfo{{ID: "E"}}
+	current["C"].State = "open"
+	changes = diffSnapshot(snapshot, current)
+	assert.Equal(t, []string{"close C"}, summarize(changes))
+}
+
+func TestFormatRestoreChanges(t *testing.T) {
+	snapshot := testSnapshot()
+	root := &snapshot.Root
+	b, c := &root.Children[0], &root.Children[1]
+
+	assert.Equal(t, "No changes. The hierarchy matches the snapshot.\n", formatRestoreChanges(nil, "owner/repo"))
+
+	changes := []restoreChange{
+		{Action: restoreLink, Issue: c, Parent: root},
+		{Action: restoreReorder, Issue: b, Order: []*SnapshotIssue{&b.Children[1], &b.Children[0]}},
+		{Action: restoreClose, Issue: c},
+		{Action: restoreReopen, Issue: b},
+	}
+
+	expected := "  → link     #3 under #1\n" +
+		"  ↕ reorder  sub-issues of #2: other/lib#5, #4\n" +
+		"  ~ close    #3 as not planned\n" +
+		"  ~ reopen   #2\n" +
+		"\nRestore: 1 to link, 1 to reorder, 1 to close, 1 to reopen.\n"
+	assert.Equal(t, expected, formatRestoreChanges(changes, "owner/repo"))
+}
+
+// graphQLResponder answers every GraphQL request with the same body
+type graphQLResponder string
+
+func (r graphQLResponder) RoundTrip(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(string(r))),
+		Request:    req,
+	}, nil
+}
+
+func TestGetCurrentIssue(t *testing.T) {
+	newClient := func(body string) *api.GraphQLClient {
+		client, err := api.NewGraphQLClient(api.ClientOptions{
+			Host:      "github.com",
+			AuthToken: "token",
+			Transport: graphQLResponder(body),
+		})
+		assert.NoError(t, err)
+		return client
+	}
+
+	t.Run("exists", func(t *testing.T) {
+		client := newClient(`{"data": {"node": {"state": "OPEN", "subIssues": {"nodes": [{"id": "B", "number": 2, "state": "CLOSED"}]}}}}`)
+		issue, err := getCurrentIssue(client, "A")
+		assert.NoError(t, err)
+		if assert.NotNil(t, issue) {
+			assert.Equal(t, "open", issue.State)
+			assert.Equal(t, "B", issue.Children[0].ID)
+		}
+	})
+
+	t.Run("deleted", func(t *testing.T) {
+		client := newClient(`{"data": {"node": null}, "errors": [{"type": "NOT_FOUND", "path": ["node"],
+			"message": "Could not resolve to a node with the global id of 'A'"}]}`)
+		issue, err := getCurrentIssue(client, "A")
+		assert.NoError(t, err)
+		assert.Nil(t, issue)
+	})
+
+	t.Run("other error", func(t *testing.T) {
+		client := newClient(`{"data": null, "errors": [{"type": "FORBIDDEN", "message": "Resource not accessible"}]}`)
+		_, err := getCurrentIssue(client, "A")
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "Resource not accessible")
+		}
+	})
+}
@@ -0,0 +1,1233 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// specMarkerPattern matches the hidden marker that ties an issue to an item of a named spec
+var specMarkerPattern = regexp.MustCompile(`<!-- gh-sub-issue:spec=([^\s:]+):id=(\S+) -->`)
+
+// Spec change actions
+const (
+	specCreate  = "create"
+	specUpdate  = "update"
+	specLink    = "link"
+	specUnlink  = "unlink"
+	specReorder = "reorder"
+)
+
+var (
+	specRepoFlag  string
+	specJSONFlag  bool
+	specForceFlag bool
+)
+
+var planCmd = &cobra.Command{
+	Use:   "plan <spec-file>",
+	Short: "Show the changes needed to make issues match a spec",
+	Long: `Compare a declarative YAML spec of an issue hierarchy with the issues on GitHub
+and show what apply would change: issues to create, fields to update, sub-issues
+to link, unlink or reorder.
+
+A spec lists issues with their sub-issues:
+
+  name: roadmap            # optional, defaults to the file name without extension
+  repository: owner/repo   # optional, defaults to --repo or the current repository
+  parent: 100              # optional, existing issue to put the top-level issues under
+  issues:
+    - id: launch           # stable key, stored with the name in a hidden marker
+      title: Launch v2
+      labels: [epic]
+      milestone: v2.0
+      children:
+        - id: backend
+          title: Backend
+          assignees: [alice]
+        - number: 45       # an existing issue
+          state: closed
+
+Every issue needs an id or a number. Ids only need to be unique within a spec:
+the marker also holds the spec name. Only the fields given in the spec are
+managed. When children is given, it is the complete, ordered list of
+sub-issues: others are unlinked.
+
+Examples:
+  gh sub-issue plan roadmap.yaml
+  gh sub-issue plan roadmap.yaml --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPlan,
+}
+
+var applyCmd = &cobra.Command{
+	Use:   "apply <spec-file>",
+	Short: "Create and update issues to match a spec",
+	Long: `Apply the changes shown by plan. Issues created by apply carry a hidden marker
+with their spec id, so running apply again changes nothing unless the spec or
+the issues changed.
+
+See 'gh sub-issue plan --help' for the spec format.
+
+Examples:
+  gh sub-issue apply roadmap.yaml
+
+  # Show the mutations without sending them
+  gh sub-issue apply roadmap.yaml --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runApply,
+}
+
+func init() {
+	rootCmd.AddCommand(planCmd)
+	rootCmd.AddCommand(applyCmd)
+	for _, cmd := range []*cobra.Command{planCmd, applyCmd} {
+		cmd.Flags().StringVarP(&specRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+	}
+	planCmd.Flags().BoolVar(&specJSONFlag, "json", false, "Output the changes as JSON")
+	applyCmd.Flags().BoolVarP(&specForceFlag, "force", "f", false, "Skip confirmation prompt")
+}
+
+// specItem is an issue of a spec. Nil fields are not managed.
+type specItem struct {
+	ID        string      `yaml:"id"`
+	Number    int         `yaml:"number"`
+	Title     *string     `yaml:"title"`
+	Body      *string     `yaml:"body"`
+	Labels    *stringList `yaml:"labels"`
+	Assignees *stringList `yaml:"assignees"`
+	Milestone *string     `yaml:"milestone"`
+	State     *string     `yaml:"state"`
+	Children  *[]specItem `yaml:"children"`
+}
+
+// key identifies the item within the spec
+func (i *specItem) key() string {
+	if i.ID != "" {
+		return i.ID
+	}
+	return fmt.Sprintf("#%d", i.Number)
+}
+
+// treeSpec is a declarative issue hierarchy
+type treeSpec struct {
+	Name       string     `yaml:"name"`
+	Repository string     `yaml:"repository"`
+	Parent     int        `yaml:"parent"`
+	Issues     []specItem `yaml:"issues"`
+}
+
+// parseTreeSpec parses and validates a spec
+func parseTreeSpec(content string) (*treeSpec, error) {
+	var spec treeSpec
+	if err := yaml.Unmarshal([]byte(content), &spec); err != nil {
+		return nil, fmt.Errorf("invalid spec: %w", err)
+	}
+	if len(spec.Issues) == 0 {
+		return nil, fmt.Errorf("invalid spec: no issues")
+	}
+	if strings.ContainsAny(spec.Name, " \t\n:") {
+		return nil, fmt.Errorf("invalid spec: name '%s' contains whitespace or ':'", spec.Name)
+	}
+
+	seen := make(map[string]bool)
+	var validate func(items []specItem, path string) error
+	validate = func(items []specItem, path string) error {
+		for i := range items {
+			item := &items[i]
+			position := fmt.Sprintf("%s%d", path, i+1)
+			if item.ID == "" && item.Number <= 0 {
+				return fmt.Errorf("invalid spec: item %s needs an id or a number", position)
+			}
+			if strings.ContainsAny(item.ID, " \t\n") {
+				return fmt.Errorf("invalid spec: item %s has an id with whitespace", position)
+			}
+			if seen[item.key()] {
+				return fmt.Errorf("invalid spec: %s appears more than once", item.key())
+			}
+			seen[item.key()] = true
+			if item.State != nil && *item.State != "open" && *item.State != "closed" {
+				return fmt.Errorf("invalid spec: item %s has invalid state '%s' (expected open or closed)", position, *item.State)
+			}
+			if item.Children != nil {
+				if err := validate(*item.Children, position+"."); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err := validate(spec.Issues, ""); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// walk calls fn for every item of the spec, parents before children
+func (s *treeSpec) walk(fn func(item *specItem, parent *specItem)) {
+	var visit func(items []specItem, parent *specItem)
+	visit = func(items []specItem, parent *specItem) {
+		for i := range items {
+			fn(&items[i], parent)
+			if items[i].Children != nil {
+				visit(*items[i].Children, &items[i])
+			}
+		}
+	}
+	visit(s.Issues, nil)
+}
+
+// specName returns the name of a spec file to use in markers: its base name without
+// extension, with whitespace and ':' replaced
+func specName(path string) string {
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return strings.Map(func(r rune) rune {
+		if r == ':' || r == ' ' || r == '\t' || r == '\n' {
+			return '-'
+		}
+		return r
+	}, name)
+}
+
+// specMarker returns the hidden marker for an id of the named spec
+func specMarker(spec, id string) string {
+	return fmt.Sprintf("<!-- gh-sub-issue:spec=%s:id=%s -->", spec, id)
+}
+
+// stripSpecMarker removes the marker from a body
+func stripSpecMarker(body string) string {
+	return strings.TrimSpace(specMarkerPattern.ReplaceAllString(body, ""))
+}
+
+// withSpecMarker returns the body with the marker of an id of the named spec appended
+func withSpecMarker(body, spec, id string) string {
+	body = stripSpecMarker(body)
+	if id == "" {
+		return body
+	}
+	if body == "" {
+		return specMarker(spec, id)
+	}
+	return body + "\n\n" + specMarker(spec, id)
+}
+
+// specIssue is the current state of an issue managed by a spec
+type specIssue struct {
+	ID           string
+	Number       int
+	Title        string
+	Body         string
+	State        string
+	Labels       []string
+	Assignees    []string
+	Milestone    string
+	ParentNumber int
+	Children     []IssueInfo
+
+	labelCount    int
+	assigneeCount int
+}
+
+// missingFields describes the labels or assignees of the issue that were not all fetched
+func (i *specIssue) missingFields() []string {
+	var missing []string
+	if i.labelCount > len(i.Labels) {
+		missing = append(missing, fmt.Sprintf("#%d has %d labels, only %d were fetched",
+			i.Number, i.labelCount, len(i.Labels)))
+	}
+	if i.assigneeCount > len(i.Assignees) {
+		missing = append(missing, fmt.Sprintf("#%d has %d assignees, only %d were fetched",
+			i.Number, i.assigneeCount, len(i.Assignees)))
+	}
+	return missing
+}
+
+// SpecChange is a change needed to make the issues match the spec
+type SpecChange struct {
+	Action  string   `json:"action"`
+	Key     string   `json:"key"`
+	Number  int      `json:"number,omitempty"`
+	Title   string   `json:"title,omitempty"`
+	Parent  string   `json:"parent,omitempty"`
+	Details []string `json:"details,omitempty"`
+
+	item    *specItem
+	current *specIssue
+	// order holds the keys of the sub-issues in their new order, for reorders
+	order []string
+	// move is set when a link replaces an existing parent
+	move bool
+}
+
+// specState is the current state of the issues of a spec
+type specState struct {
+	// numbers maps spec keys to issue numbers, for issues that exist
+	numbers map[string]int
+	// issues maps issue numbers to their state
+	issues map[int]*specIssue
+}
+
+// reference returns "#N" for existing issues and the spec key otherwise
+func (st *specState) reference(key string) string {
+	if number, ok := st.numbers[key]; ok {
+		return fmt.Sprintf("#%d", number)
+	}
+	return key
+}
+
+// diffSpec computes the changes that make the issues match the spec
+func diffSpec(spec *treeSpec, st *specState) ([]SpecChange, error) {
+	// Issues that the spec puts under a parent; moving them is a link, not an unlink
+	placed := make(map[int]bool)
+	spec.walk(func(item *specItem, parent *specItem) {
+		if parent != nil || spec.Parent > 0 {
+			placed[st.numbers[item.key()]] = true
+		}
+	})
+
+	var changes []SpecChange
+	var visit func(items []specItem, parentKey string, parentNumber int) error
+	visit = func(items []specItem, parentKey string, parentNumber int) error {
+		for i := range items {
+			item := &items[i]
+			key := item.key()
+			number := st.numbers[key]
+			current := st.issues[number]
+
+			if current == nil {
+				if item.Number > 0 {
+					return fmt.Errorf("issue #%d not found", item.Number)
+				}
+				if item.Title == nil || strings.TrimSpace(*item.Title) == "" {
+					return fmt.Errorf("%s does not exist yet and needs a title", key)
+				}
+				changes = append(changes, SpecChange{Action: specCreate, Key: key, Title: *item.Title,
+					Parent: parentKey, item: item})
+			} else {
+				if details := specFieldChanges(item, current); len(details) > 0 {
+					changes = append(changes, SpecChange{Action: specUpdate, Key: key, Number: number,
+						Title: current.Title, Details: details, item: item, current: current})
+				}
+				if parentKey != "" && (parentNumber == 0 || current.ParentNumber != parentNumber) {
+					changes = append(changes, SpecChange{Action: specLink, Key: key, Number: number,
+						Title: current.Title, Parent: parentKey, item: item, current: current,
+						move: current.ParentNumber != 0})
+				}
+			}
+
+			if item.Children == nil {
+				continue
+			}
+			if err := visit(*item.Children, key, number); err != nil {
+				return err
+			}
+			if current != nil {
+				changes = append(changes, specChildChanges(item, current, st, placed)...)
+			}
+		}
+		return nil
+	}
+
+	parentKey := ""
+	if spec.Parent > 0 {
+		parentKey = fmt.Sprintf("#%d", spec.Parent)
+	}
+	if err := visit(spec.Issues, parentKey, spec.Parent); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// specChildChanges returns the unlinks and the reorder needed for the sub-issues of an existing issue
+func specChildChanges(item *specItem, current *specIssue, st *specState, placed map[int]bool) []SpecChange {
+	var changes []SpecChange
+	key := item.key()
+
+	wanted := make(map[int]bool)
+	for i := range *item.Children {
+		if number := st.numbers[(*item.Children)[i].key()]; number > 0 {
+			wanted[number] = true
+		}
+	}
+
+	// Sub-issues that stay keep their order; new ones are appended in spec order
+	var predicted []string
+	kept := make(map[int]bool)
+	for _, child := range current.Children {
+		if wanted[child.Number] {
+			kept[child.Number] = true
+			predicted = append(predicted, fmt.Sprintf("#%d", child.Number))
+			continue
+		}
+		if placed[child.Number] {
+			continue
+		}
+		changes = append(changes, SpecChange{Action: specUnlink, Key: fmt.Sprintf("#%d", child.Number),
+			Number: child.Number, Title: child.Title, Parent: key, current: &specIssue{ID: child.ID, Number: child.Number}})
+	}
+
+	var desired []string
+	for i := range *item.Children {
+		childKey := (*item.Children)[i].key()
+		ref := st.reference(childKey)
+		desired = append(desired, childKey)
+		if !kept[st.numbers[childKey]] {
+			predicted = append(predicted, ref)
+		}
+	}
+
+	var desiredRefs []string
+	for _, childKey := range desired {
+		desiredRefs = append(desiredRefs, st.reference(childKey))
+	}
+	if strings.Join(predicted, ",") != strings.Join(desiredRefs, ",") {
+		changes = append(changes, SpecChange{Action: specReorder, Key: key, Number: current.Number,
+			Title: current.Title, Details: desiredRefs, order: desired})
+	}
+	return changes
+}
+
+// specFieldChanges describes the managed fields of an item that differ from the issue
+func specFieldChanges(item *specItem, current *specIssue) []string {
+	var details []string
+
+	if item.Title != nil && *item.Title != current.Title {
+		details = append(details, fmt.Sprintf("title: %q → %q", current.Title, *item.Title))
+	}
+	if item.Body != nil && strings.TrimSpace(*item.Body) != stripSpecMarker(current.Body) {
+		details = append(details, "body: changed")
+	}
+	if item.Labels != nil {
+		if diff := nameSetChanges(current.Labels, *item.Labels); diff != "" {
+			details = append(details, "labels: "+diff)
+		}
+	}
+	if item.Assignees != nil {
+		if diff := nameSetChanges(current.Assignees, *item.Assignees); diff != "" {
+			details = append(details, "assignees: "+diff)
+		}
+	}
+	if item.Milestone != nil && !strings.EqualFold(*item.Milestone, current.Milestone) {
+		details = append(details, fmt.Sprintf("milestone: %s → %s", orNone(current.Milestone), orNone(*item.Milestone)))
+	}
+	if item.State != nil && *item.State != current.State {
+		details = append(details, fmt.Sprintf("state: %s → %s", current.State, *item.State))
+	}
+
+	return details
+}
+
+// nameSetChanges describes the names to add and remove, ignoring case and order
+func nameSetChanges(current, desired []string) string {
+	var parts []string
+	for _, name := range desired {
+		if !hasLabel(current, name) {
+			parts = append(parts, "+"+name)
+		}
+	}
+	for _, name := range current {
+		if !hasLabel(desired, name) {
+			parts = append(parts, "-"+name)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// orNone returns "none" for empty values
+func orNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
+
+// specChangeSymbols prefix each action in the plan
+var specChangeSymbols = map[string]string{
+	specCreate:  "+",
+	specUpdate:  "~",
+	specLink:    "→",
+	specUnlink:  "-",
+	specReorder: "↕",
+}
+
+// formatSpecChanges formats the changes like a Terraform plan
+func formatSpecChanges(changes []SpecChange, st *specState) string {
+	var output strings.Builder
+
+	if len(changes) == 0 {
+		output.WriteString("No changes. The issues match the spec.\n")
+		return output.String()
+	}
+
+	counts := make(map[string]int)
+	for _, change := range changes {
+		counts[change.Action]++
+		symbol := specChangeSymbols[change.Action]
+		ref := st.reference(change.Key)
+		if change.Number > 0 {
+			ref = fmt.Sprintf("#%d", change.Number)
+		}
+
+		switch change.Action {
+		case specCreate:
+			line := fmt.Sprintf("  %s create   %s: %q", symbol, change.Key, change.Title)
+			if change.Parent != "" {
+				line += fmt.Sprintf(" (under %s)", st.reference(change.Parent))
+			}
+			output.WriteString(line + "\n")
+		case specUpdate:
+			output.WriteString(fmt.Sprintf("  %s update   %s %s\n", symbol, ref, change.Title))
+			for _, detail := range change.Details {
+				output.WriteString(fmt.Sprintf("        %s\n", detail))
+			}
+		case specLink:
+			output.WriteString(fmt.Sprintf("  %s link     %s under %s\n", symbol, ref, st.reference(change.Parent)))
+		case specUnlink:
+			output.WriteString(fmt.Sprintf("  %s unlink   %s from %s\n", symbol, ref, st.reference(change.Parent)))
+		case specReorder:
+			output.WriteString(fmt.Sprintf("  %s reorder  sub-issues of %s: %s\n", symbol, ref, strings.Join(change.Details, ", ")))
+		}
+	}
+
+	output.WriteString(fmt.Sprintf("\nPlan: %d to create, %d to update, %d to link, %d to unlink, %d to reorder.\n",
+		counts[specCreate], counts[specUpdate], counts[specLink], counts[specUnlink], counts[specReorder]))
+	return output.String()
+}
+
+// loadSpec reads the spec file, names it after the file unless it has a name and
+// resolves its repository
+func loadSpec(path string) (*treeSpec, string, string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", "", fmt.Errorf("failed to read spec: %w", err)
+	}
+	spec, err := parseTreeSpec(string(content))
+	if err != nil {
+		return nil, "", "", err
+	}
+	if spec.Name == "" {
+		spec.Name = specName(path)
+	}
+
+	repository := spec.Repository
+	if specRepoFlag != "" {
+		repository = specRepoFlag
+	}
+	if repository != "" {
+		parts := strings.Split(repository, "/")
+		if len(parts) != 2 {
+			return nil, "", "", fmt.Errorf("invalid repository format: %s (expected OWNER/REPO)", repository)
+		}
+		return spec, parts[0], parts[1], nil
+	}
+
+	owner, repo, err := getDefaultRepo()
+	if err != nil {
+		return nil, "", "", fmt.Errorf("no repository specified and could not determine from current directory: %w", err)
+	}
+	return spec, owner, repo, nil
+}
+
+// fetchSpecState finds the issues of a spec and their current state
+func fetchSpecState(w io.Writer, client *api.GraphQLClient, owner, repo string, spec *treeSpec) (*specState, error) {
+	st := &specState{numbers: make(map[string]int), issues: make(map[int]*specIssue)}
+
+	hasIDs := false
+	spec.walk(func(item *specItem, parent *specItem) {
+		if item.Number > 0 {
+			st.numbers[item.key()] = item.Number
+		} else {
+			hasIDs = true
+		}
+	})
+
+	if hasIDs {
+		fmt.Fprintf(w, "Finding issues created from spec '%s' in %s/%s...\n", spec.Name, owner, repo)
+		marked, err := searchSpecMarkers(client, owner, repo, spec.Name)
+		if err != nil {
+			return nil, err
+		}
+		spec.walk(func(item *specItem, parent *specItem) {
+			if number, ok := marked[item.ID]; ok && item.Number == 0 {
+				st.numbers[item.ID] = number
+			}
+		})
+	}
+
+	numbers := []int{}
+	if spec.Parent > 0 {
+		st.numbers[fmt.Sprintf("#%d", spec.Parent)] = spec.Parent
+		numbers = append(numbers, spec.Parent)
+	}
+	spec.walk(func(item *specItem, parent *specItem) {
+		if number, ok := st.numbers[item.key()]; ok {
+			numbers = append(numbers, number)
+		}
+	})
+
+	// Issues created by a recent apply may not be searchable yet, so the sub-issues of
+	// the issues found are checked for markers too
+	for i := 0; i < len(numbers); i++ {
+		number := numbers[i]
+		if _, ok := st.issues[number]; ok {
+			continue
+		}
+		fmt.Fprintf(w, "Getting issue #%d...\n", number)
+		issue, err := getSpecIssue(client, owner, repo, number)
+		if err != nil {
+			return nil, err
+		}
+		st.issues[number] = issue
+
+		if !st.hasUnresolvedIDs(spec) {
+			continue
+		}
+		marked, err := childSpecMarkers(client, owner, repo, spec.Name, issue.Children)
+		if err != nil {
+			return nil, err
+		}
+		spec.walk(func(item *specItem, parent *specItem) {
+			if _, ok := st.numbers[item.key()]; ok {
+				return
+			}
+			if number, ok := marked[item.ID]; ok {
+				st.numbers[item.ID] = number
+				numbers = append(numbers, number)
+			}
+		})
+	}
+	return st, nil
+}
+
+// hasUnresolvedIDs reports whether an item of the spec has an id but no issue yet
+func (st *specState) hasUnresolvedIDs(spec *treeSpec) bool {
+	unresolved := false
+	spec.walk(func(item *specItem, parent *specItem) {
+		if _, ok := st.numbers[item.key()]; !ok {
+			unresolved = true
+		}
+	})
+	return unresolved
+}
+
+// markedIssue is an issue whose body may carry spec markers
+type markedIssue struct {
+	Number int    `json:"number"`
+	Body   string `json:"body"`
+}
+
+// specMarkers maps the spec ids found in the bodies of the issues to issue numbers. The
+// oldest issue wins if a marker was copied.
+func specMarkers(issues []markedIssue, spec string) map[string]int {
+	sort.Slice(issues, func(i, j int) bool { return issues[i].Number < issues[j].Number })
+	marked := make(map[string]int)
+	for _, issue := range issues {
+		for _, match := range specMarkerPattern.FindAllStringSubmatch(issue.Body, -1) {
+			if _, ok := marked[match[2]]; !ok && match[1] == spec {
+				marked[match[2]] = issue.Number
+			}
+		}
+	}
+	return marked
+}
+
+// childSpecMarkers reads the bodies of the sub-issues in owner/repo and maps the spec ids
+// found in them to issue numbers
+func childSpecMarkers(client *api.GraphQLClient, owner, repo, spec string, children []IssueInfo) (map[string]int, error) {
+	var nodes []*IssueNode
+	for _, child := range children {
+		ref, err := parseIssueURL(child.URL)
+		if err == nil && ref.Owner == owner && ref.Repo == repo {
+			nodes = append(nodes, &IssueNode{ID: child.ID, Number: child.Number})
+		}
+	}
+
+	for start := 0; start < len(nodes); start += bodyBatchSize {
+		end := start + bodyBatchSize
+		if end > len(nodes) {
+			end = len(nodes)
+		}
+		if err := fetchBodyBatch(client, nodes[start:end]); err != nil {
+			return nil, err
+		}
+	}
+
+	issues := make([]markedIssue, 0, len(nodes))
+	for _, node := range nodes {
+		issues = append(issues, markedIssue{Number: node.Number, Body: node.Body})
+	}
+	return specMarkers(issues, spec), nil
+}
+
+// searchSpecMarkers finds the issues of a repository that carry a marker of the named
+// spec and maps their spec ids to issue numbers
+func searchSpecMarkers(client *api.GraphQLClient, owner, repo, spec string) (map[string]int, error) {
+	query := `
+		query($search: String!, $cursor: String) {
+			search(query: $search, type: ISSUE, first: 100, after: $cursor) {
+				nodes {
+					... on Issue {
+						number
+						body
+					}
+				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}`
+
+	// Search only narrows down the candidates; the markers are checked below
+	search := fmt.Sprintf(`repo:%s/%s is:issue in:body "gh-sub-issue:spec=%s:id="`, owner, repo, spec)
+
+	var candidates []markedIssue
+	var cursor *string
+
+	for {
+		variables := map[string]interface{}{
+			"search": search,
+			"cursor": cursor,
+		}
+
+		var response struct {
+			Search struct {
+				Nodes    []markedIssue `json:"nodes"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+			} `json:"search"`
+		}
+
+		err := client.Do(query, variables, &response)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search issues: %w", err)
+		}
+		candidates = append(candidates, response.Search.Nodes...)
+
+		if !response.Search.PageInfo.HasNextPage {
+			break
+		}
+		cursor = &response.Search.PageInfo.EndCursor
+	}
+
+	return specMarkers(candidates, spec), nil
+}
+
+// getSpecIssue gets the managed fields, parent and sub-issues of an issue
+func getSpecIssue(client *api.GraphQLClient, owner, repo string, number int) (*specIssue, error) {
+	query := `
+		query($owner: String!, $repo: String!, $number: Int!) {
+			repository(owner: $owner, name: $repo) {
+				issue(number: $number) {
+					id
+					number
+					title
+					body
+					state
+					labels(first: 100) {
+						totalCount
+						nodes {
+							name
+						}
+					}
+					assignees(first: 100) {
+						totalCount
+						nodes {
+							login
+						}
+					}
+					milestone {
+						title
+					}
+					parent {
+						number
+					}
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"owner":  owner,
+		"repo":   repo,
+		"number": number,
+	}
+
+	var response struct {
+		Repository struct {
+			Issue *struct {
+				ID     string `json:"id"`
+				Number int    `json:"number"`
+				Title  string `json:"title"`
+				Body   string `json:"body"`
+				State  string `json:"state"`
+				Labels struct {
+					TotalCount int `json:"totalCount"`
+					Nodes      []struct {
+						Name string `json:"name"`
+					} `json:"nodes"`
+				} `json:"labels"`
+				Assignees struct {
+					TotalCount int `json:"totalCount"`
+					Nodes      []struct {
+						Login string `json:"login"`
+					} `json:"nodes"`
+				} `json:"assignees"`
+				Milestone *struct {
+					Title string `json:"title"`
+				} `json:"milestone"`
+				Parent *struct {
+					Number int `json:"number"`
+				} `json:"parent"`
+			} `json:"issue"`
+		} `json:"repository"`
+	}
+
+	err := client.Do(query, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue #%d: %w", number, err)
+	}
+	r := response.Repository.Issue
+	if r == nil {
+		return nil, fmt.Errorf("issue #%d not found in %s/%s", number, owner, repo)
+	}
+
+	// All sub-issues are needed, or those past the first page would be unlinked
+	children, err := getChildIssues(client, r.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	issue := &specIssue{
+		ID:        r.ID,
+		Number:    r.Number,
+		Title:     r.Title,
+		Body:      r.Body,
+		State:     strings.ToLower(r.State),
+		Labels:    []string{},
+		Assignees: []string{},
+		Children:  children,
+
+		labelCount:    r.Labels.TotalCount,
+		assigneeCount: r.Assignees.TotalCount,
+	}
+	for _, label := range r.Labels.Nodes {
+		issue.Labels = append(issue.Labels, label.Name)
+	}
+	for _, assignee := range r.Assignees.Nodes {
+		issue.Assignees = append(issue.Assignees, assignee.Login)
+	}
+	if r.Milestone != nil {
+		issue.Milestone = r.Milestone.Title
+	}
+	if r.Parent != nil {
+		issue.ParentNumber = r.Parent.Number
+	}
+
+	// Unfetched labels or assignees would be planned again on every run
+	if missing := issue.missingFields(); len(missing) > 0 {
+		return nil, fmt.Errorf("issue #%d is too large to manage with a spec: %s", number, strings.Join(missing, "; "))
+	}
+	return issue, nil
+}
+
+// preparePlan loads the spec, fetches the issues and computes the changes. The
+// returned applier knows the node IDs of the existing issues.
+func preparePlan(cmd *cobra.Command, path string) (*specApplier, []SpecChange, error) {
+	spec, owner, repo, err := loadSpec(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	st, err := fetchSpecState(cmd.OutOrStderr(), client, owner, repo, spec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	changes, err := diffSpec(spec, st)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	applier := &specApplier{
+		w:      cmd.OutOrStderr(),
+		name:   spec.Name,
+		client: client,
+		owner:  owner,
+		repo:   repo,
+		st:     st,
+		ids:    make(map[string]string),
+	}
+	for key, number := range st.numbers {
+		applier.ids[key] = st.issues[number].ID
+	}
+	return applier, changes, nil
+}
+
+func runPlan(cmd *cobra.Command, args []string) error {
+	applier, changes, err := preparePlan(cmd, args[0])
+	if err != nil {
+		return err
+	}
+
+	if specJSONFlag {
+		if changes == nil {
+			changes = []SpecChange{}
+		}
+		jsonBytes, err := json.MarshalIndent(changes, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(jsonBytes))
+		return nil
+	}
+
+	fmt.Fprint(cmd.OutOrStdout(), formatSpecChanges(changes, applier.st))
+	return nil
+}
+
+func runApply(cmd *cobra.Command, args []string) error {
+	applier, changes, err := preparePlan(cmd, args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprint(cmd.OutOrStderr(), formatSpecChanges(changes, applier.st))
+	if len(changes) == 0 {
+		return nil
+	}
+
+	// Confirm unless forced or only planning
+	if !specForceFlag && !dryRunFlag {
+		fmt.Fprint(cmd.OutOrStderr(), "\nApply these changes? (y/N): ")
+		var response string
+		fmt.Scanln(&response)
+		if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
+			fmt.Fprintln(cmd.OutOrStderr(), "Apply cancelled")
+			return nil
+		}
+	}
+
+	for i, change := range changes {
+		ref, err := applier.apply(change)
+		if err != nil {
+			return fmt.Errorf("apply stopped after %d of %d changes: %s %s: %w", i, len(changes), change.Action, change.Key, err)
+		}
+		if !dryRunFlag {
+			fmt.Fprintf(cmd.OutOrStdout(), "✓ %s %s\n", specChangePast(change.Action), ref)
+		}
+	}
+
+	if dryRunFlag {
+		return writePlan(cmd.OutOrStdout(), !term.IsTerminal(os.Stdout))
+	}
+	return nil
+}
+
+// specChangePast returns the past tense of an action for the change log
+func specChangePast(action string) string {
+	switch action {
+	case specCreate:
+		return "Created"
+	case specUpdate:
+		return "Updated"
+	case specLink:
+		return "Linked"
+	case specUnlink:
+		return "Unlinked"
+	default:
+		return "Reordered sub-issues of"
+	}
+}
+
+// specApplier applies spec changes, tracking the node IDs of created issues
+type specApplier struct {
+	w      io.Writer
+	name   string
+	client *api.GraphQLClient
+	owner  string
+	repo   string
+	repoID string
+	st     *specState
+	// ids maps spec keys to issue node IDs
+	ids map[string]string
+}
+
+// apply makes one change and returns a reference to the issue it changed
+func (a *specApplier) apply(change SpecChange) (string, error) {
+	ref := a.st.reference(change.Key)
+
+	switch change.Action {
+	case specCreate:
+		if a.repoID == "" {
+			repoID, err := getRepositoryID(a.client, a.owner, a.repo)
+			if err != nil {
+				return ref, err
+			}
+			a.repoID = repoID
+		}
+
+		item := change.item
+		issue := &newSubIssue{Title: *item.Title}
+		if item.Body != nil {
+			issue.Body = *item.Body
+		}
+		issue.Body = withSpecMarker(issue.Body, a.name, item.ID)
+		if item.Labels != nil {
+			issue.Labels = *item.Labels
+		}
+		if item.Assignees != nil {
+			issue.Assignees = *item.Assignees
+		}
+		if item.Milestone != nil {
+			issue.Milestone = *item.Milestone
+		}
+
+		info, err := createSubIssueWithMetadata(a.w, a.client, a.owner, a.repo, a.repoID, a.ids[change.Parent], issue)
+		if err != nil {
+			return ref, err
+		}
+		a.ids[change.Key] = info.ID
+		if info.Number > 0 {
+			ref = fmt.Sprintf("#%d %s", info.Number, info.Title)
+		}
+		if item.State != nil && *item.State == "closed" {
+			if err := closeIssue(a.client, info.ID, "COMPLETED"); err != nil {
+				return ref, err
+			}
+		}
+		return ref, nil
+
+	case specUpdate:
+		return ref, a.update(change.item, change.current)
+
+	case specLink:
+		parentID := a.ids[change.Parent]
+		if change.move {
+			return ref, moveSubIssue(a.client, parentID, change.current.ID)
+		}
+		_, _, err := addSubIssue(a.client, parentID, change.current.ID)
+		return ref, err
+
+	case specUnlink:
+		return ref, removeSubIssue(a.client, a.ids[change.Parent], change.current.ID)
+
+	case specReorder:
+		parentID := a.ids[change.Key]
+		for i := 1; i < len(change.order); i++ {
+			err := reprioritizeSubIssue(a.client, parentID, a.ids[change.order[i]], a.ids[change.order[i-1]])
+			if err != nil {
+				return ref, err
+			}
+		}
+		return ref, nil
+	}
+
+	return ref, fmt.Errorf("unknown change %s", change.Action)
+}
+
+// labelIDs gets the node IDs of the labels. Unlike create, a missing label is an error:
+// applying the rest would leave a change that shows up in every plan.
+func (a *specApplier) labelIDs(names []string) ([]string, error) {
+	repoLabels, err := listLabels(a.client, a.owner, a.repo)
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]string)
+	for _, label := range repoLabels {
+		ids[strings.ToLower(label.Name)] = label.ID
+	}
+
+	labelIDs := []string{}
+	for _, name := range names {
+		id, ok := ids[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("label '%s' not found in %s/%s", name, a.owner, a.repo)
+		}
+		labelIDs = append(labelIDs, id)
+	}
+	return labelIDs, nil
+}
+
+// update sets the managed fields of an existing issue that differ from the spec
+func (a *specApplier) update(item *specItem, current *specIssue) error {
+	input := map[string]interface{}{"id": current.ID}
+
+	if item.Title != nil && *item.Title != current.Title {
+		input["title"] = *item.Title
+	}
+	if item.Body != nil && strings.TrimSpace(*item.Body) != stripSpecMarker(current.Body) {
+		// Keep the marker that apply finds the issue by
+		name, id := "", ""
+		if match := specMarkerPattern.FindStringSubmatch(current.Body); match != nil {
+			name, id = match[1], match[2]
+		}
+		input["body"] = withSpecMarker(*item.Body, name, id)
+	}
+	if item.Labels != nil && nameSetChanges(current.Labels, *item.Labels) != "" {
+		labelIDs, err := a.labelIDs(*item.Labels)
+		if err != nil {
+			return err
+		}
+		input["labelIds"] = labelIDs
+	}
+	if item.Assignees != nil && nameSetChanges(current.Assignees, *item.Assignees) != "" {
+		userIDs, err := getUserIDs(a.client, *item.Assignees)
+		if err != nil {
+			return err
+		}
+		input["assigneeIds"] = append([]string{}, userIDs...)
+	}
+	if item.Milestone != nil && !strings.EqualFold(*item.Milestone, current.Milestone) {
+		milestoneID := ""
+		if *item.Milestone != "" {
+			var err error
+			milestoneID, err = findMilestoneID(a.client, a.owner, a.repo, *item.Milestone)
+			if err != nil {
+				return err
+			}
+		}
+		if milestoneID == "" && *item.Milestone != "" {
+			return fmt.Errorf("milestone '%s' not found", *item.Milestone)
+		}
+		if milestoneID == "" {
+			input["milestoneId"] = nil
+		} else {
+			input["milestoneId"] = milestoneID
+		}
+	}
+
+	if len(input) > 1 {
+		if err := updateIssueFields(a.client, input); err != nil {
+			return err
+		}
+	}
+
+	if item.State != nil && *item.State != current.State {
+		if *item.State == "closed" {
+			return closeIssue(a.client, current.ID, "COMPLETED")
+		}
+		return reopenIssue(a.client, current.ID)
+	}
+	return nil
+}
+
+// updateIssueFields updates an issue with an UpdateIssueInput
+func updateIssueFields(client *api.GraphQLClient, input map[string]interface{}) error {
+	mutation := `
+		mutation UpdateIssue($input: UpdateIssueInput!) {
+			updateIssue(input: $input) {
+				issue {
+					number
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"input": input,
+	}
+
+	var response struct {
+		UpdateIssue struct {
+			Issue struct {
+				Number int `json:"number"`
+			} `json:"issue"`
+		} `json:"updateIssue"`
+	}
+
+	err := doMutation(client, "updateIssue", "Update issue", mutation, variables, &response)
+	if err != nil {
+		return fmt.Errorf("failed to update issue: %w", err)
+	}
+
+	return nil
+}
+
+// moveSubIssue links an issue to a parent, replacing its current parent
+func moveSubIssue(client *api.GraphQLClient, parentID, subIssueID string) error {
+	mutation := `
+		mutation($parentId: ID!, $subIssueId: ID!) {
+			addSubIssue(input: {
+				issueId: $parentId,
+				subIssueId: $subIssueId,
+				replaceParent: true
+			}) {
+				subIssue {
+					number
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"parentId":   parentID,
+		"subIssueId": subIssueID,
+	}
+
+	var response struct {
+		AddSubIssue struct {
+			SubIssue struct {
+				Number int `json:"number"`
+			} `json:"subIssue"`
+		} `json:"addSubIssue"`
+	}
+
+	err := doMutation(client, "addSubIssue", "Move sub-issue to another parent", mutation, variables, &response)
+	if err != nil {
+		return fmt.Errorf("failed to move sub-issue: %w", err)
+	}
+
+	return nil
+}
+
+// reprioritizeSubIssue moves a sub-issue right after another sub-issue of the same parent
+func reprioritizeSubIssue(client *api.GraphQLClient, parentID, subIssueID, afterID string) error {
+	mutation := `
+		mutation($parentId: ID!, $subIssueId: ID!, $afterId: ID!) {
+			reprioritizeSubIssue(input: {
+				issueId: $parentId,
+				subIssueId: $subIssueId,
+				afterId: $afterId
+			}) {
+				issue {
+					number
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"parentId":   parentID,
+		"subIssueId": subIssueID,
+		"afterId":    afterID,
+	}
+
+	var response struct {
+		ReprioritizeSubIssue struct {
+			Issue struct {
+				Number int `json:"number"`
+			} `json:"issue"`
+		} `json:"reprioritizeSubIssue"`
+	}
+
+	err := doMutation(client, "reprioritizeSubIssue", "Reorder sub-issue", mutation, variables, &response)
+	if err != nil {
+		return fmt.Errorf("failed to reorder sub-issue: %w", err)
+	}
+
+	return nil
+}
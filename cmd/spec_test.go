@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSpec = `
+issues:
+  - id: launch
+    title: Launch v2
+    labels: [epic]
+    children:
+      - id: backend
+        title: Backend
+      - number: 45
+        state: closed
+`
+
+func TestParseTreeSpec(t *testing.T) {
+	spec, err := parseTreeSpec(testSpec)
+	assert.NoError(t, err)
+	assert.Len(t, spec.Issues, 1)
+	assert.Equal(t, "launch", spec.Issues[0].key())
+	assert.Equal(t, stringList{"epic"}, *spec.Issues[0].Labels)
+	assert.Nil(t, spec.Issues[0].Assignees)
+
+	children := *spec.Issues[0].Children
+	assert.Equal(t, "backend", children[0].key())
+	assert.Equal(t, "#45", children[1].key())
+	assert.Nil(t, children[0].Children)
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"no issues", "issues: []", "no issues"},
+		{"no key", "issues:\n  - title: A", "item 1 needs an id or a number"},
+		{"nested no key", "issues:\n  - id: a\n    children:\n      - title: B", "item 1.1 needs an id or a number"},
+		{"duplicate", "issues:\n  - id: a\n  - id: a", "a appears more than once"},
+		{"whitespace id", "issues:\n  - id: a b", "id with whitespace"},
+		{"bad state", "issues:\n  - id: a\n    state: done", "invalid state 'done'"},
+		{"bad name", "name: q3:plan\nissues:\n  - id: a", "name 'q3:plan' contains whitespace or ':'"},
+		{"bad yaml", "issues: [", "invalid spec"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseTreeSpec(tt.content)
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSpecMarker(t *testing.T) {
+	assert.Equal(t, "<!-- gh-sub-issue:spec=roadmap:id=launch -->", withSpecMarker("", "roadmap", "launch"))
+	assert.Equal(t, "Body\n\n<!-- gh-sub-issue:spec=roadmap:id=launch -->", withSpecMarker("Body\n", "roadmap", "launch"))
+	assert.Equal(t, "Body\n\n<!-- gh-sub-issue:spec=roadmap:id=new -->",
+		withSpecMarker("Body\n\n<!-- gh-sub-issue:spec=roadmap:id=old -->", "roadmap", "new"))
+	assert.Equal(t, "Body", withSpecMarker("Body", "roadmap", ""))
+	assert.Equal(t, "Body", stripSpecMarker("Body\n\n<!-- gh-sub-issue:spec=roadmap:id=launch -->"))
+
+	// Ids may contain ':', spec names may not
+	match := specMarkerPattern.FindStringSubmatch(specMarker("q3", "api:v2"))
+	assert.Equal(t, []string{"q3", "api:v2"}, match[1:])
+
+	assert.Equal(t, "roadmap", specName("specs/roadmap.yaml"))
+	assert.Equal(t, "q3-plan-v2", specName("q3 plan:v2.yml"))
+}
+
+func TestSpecMarkers(t *testing.T) {
+	issues := []markedIssue{
+		{Number: 9, Body: "Copy\n\n<!-- gh-sub-issue:spec=roadmap:id=api -->"},
+		{Number: 4, Body: "<!-- gh-sub-issue:spec=roadmap:id=api -->"},
+		{Number: 5, Body: "<!-- gh-sub-issue:spec=other:id=docs -->"},
+	}
+	// The oldest issue wins and markers of other specs are ignored
+	assert.Equal(t, map[string]int{"api": 4}, specMarkers(issues, "roadmap"))
+}
+
+func TestChildSpecMarkers(t *testing.T) {
+	client, err := api.NewGraphQLClient(api.ClientOptions{
+		Host:      "github.com",
+		AuthToken: "token",
+		Transport: graphQLResponder(`{"data": {"n0": {"body": "<!-- gh-sub-issue:spec=roadmap:id=api -->"}, "n1": {"body": ""}}}`),
+	})
+	assert.NoError(t, err)
+
+	children := []IssueInfo{
+		{ID: "A", Number: 2, URL: "https://github.com/owner/repo/issues/2"},
+		{ID: "B", Number: 3, URL: "https://github.com/owner/repo/issues/3"},
+		{ID: "C", Number: 2, URL: "https://github.com/other/lib/issues/2"},
+	}
+	marked, err := childSpecMarkers(client, "owner", "repo", "roadmap", children)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"api": 2}, marked)
+}
+
+func TestSpecApplierLabelIDs(t *testing.T) {
+	client, err := api.NewGraphQLClient(api.ClientOptions{
+		Host:      "github.com",
+		AuthToken: "token",
+		Transport: graphQLResponder(`{"data": {"repository": {"labels": {"nodes": [{"id": "L1", "name": "bug"}]}}}}`),
+	})
+	assert.NoError(t, err)
+	applier := &specApplier{client: client, owner: "owner", repo: "repo"}
+
+	ids, err := applier.labelIDs([]string{"Bug"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"L1"}, ids)
+
+	_, err = applier.labelIDs([]string{"bug", "missing"})
+	assert.EqualError(t, err, "label 'missing' not found in owner/repo")
+}
+
+func TestGetSpecIssueTruncated(t *testing.T) {
+	newClient := func(labelCount int) *api.GraphQLClient {
+		client, err := api.NewGraphQLClient(api.ClientOptions{
+			Host:      "github.com",
+			AuthToken: "token",
+			Transport: graphQLResponder(fmt.Sprintf(`{"data": {
+				"repository": {"issue": {"id": "I1", "number": 1, "title": "Epic", "state": "OPEN",
+					"labels": {"totalCount": %d, "nodes": [{"name": "epic"}]},
+					"assignees": {"totalCount": 0, "nodes": []}}},
+				"node": {"subIssues": {"nodes": [], "pageInfo": {"hasNextPage": false}}}}}`, labelCount)),
+		})
+		assert.NoError(t, err)
+		return client
+	}
+
+	issue, err := getSpecIssue(newClient(1), "owner", "repo", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"epic"}, issue.Labels)
+
+	// Labels that were not fetched would be added again on every apply
+	_, err = getSpecIssue(newClient(120), "owner", "repo", 1)
+	assert.EqualError(t, err, "issue #1 is too large to manage with a spec: #1 has 120 labels, only 1 were fetched")
+}
+
+// specActions summarizes changes as "action key" strings
+func specActions(changes []SpecChange) []string {
+	var actions []string
+	for _, change := range changes {
+		actions = append(actions, change.Action+" "+change.Key)
+	}
+	return actions
+}
+
+func TestDiffSpec(t *testing.T) {
+	// The current state after applying testSpec
+	appliedState := func() *specState {
+		return &specState{
+			numbers: map[string]int{"launch": 10, "backend": 11, "#45": 45},
+			issues: map[int]*specIssue{
+				10: {ID: "I10", Number: 10, Title: "Launch v2", State: "open", Labels: []string{"Epic"},
+					Body:     "<!-- gh-sub-issue:spec=roadmap:id=launch -->",
+					Children: []IssueInfo{{ID: "I11", Number: 11}, {ID: "I45", Number: 45}}},
+				11: {ID: "I11", Number: 11, Title: "Backend", State: "open", ParentNumber: 10},
+				45: {ID: "I45", Number: 45, Title: "Docs", State: "closed", ParentNumber: 10},
+			},
+		}
+	}
+
+	t.Run("nothing exists", func(t *testing.T) {
+		spec, _ := parseTreeSpec(testSpec)
+		st := &specState{
+			numbers: map[string]int{"#45": 45},
+			issues:  map[int]*specIssue{45: {ID: "I45", Number: 45, Title: "Docs", State: "open"}},
+		}
+		changes, err := diffSpec(spec, st)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"create launch", "create backend", "update #45", "link #45"}, specActions(changes))
+		assert.Equal(t, "launch", changes[1].Parent)
+		assert.Equal(t, []string{"state: open → closed"}, changes[2].Details)
+		assert.False(t, changes[3].move)
+	})
+
+	t.Run("applied", func(t *testing.T) {
+		spec, _ := parseTreeSpec(testSpec)
+		changes, err := diffSpec(spec, appliedState())
+		assert.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("fields", func(t *testing.T) {
+		spec, _ := parseTreeSpec(`
+issues:
+  - id: launch
+    title: Launch v3
+    body: Details
+    labels: [epic, p1]
+    assignees: []
+    milestone: v3.0
+`)
+		st := appliedState()
+		st.issues[10].Assignees = []string{"alice"}
+		changes, err := diffSpec(spec, st)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"update launch"}, specActions(changes))
+		assert.Equal(t, []string{
+			`title: "Launch v2" → "Launch v3"`,
+			"body: changed",
+			"labels: +p1",
+			"assignees: -alice",
+			"milestone: none → v3.0",
+		}, changes[0].Details)
+	})
+
+	t.Run("unlink and reorder", func(t *testing.T) {
+		spec, _ := parseTreeSpec(`
+issues:
+  - id: launch
+    children:
+      - id: frontend
+        title: Frontend
+      - id: backend
+`)
+		changes, err := diffSpec(spec, appliedState())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"create frontend", "unlink #45", "reorder launch"}, specActions(changes))
+		assert.Equal(t, []string{"frontend", "backend"}, changes[2].order)
+		assert.Equal(t, []string{"frontend", "#11"}, changes[2].Details)
+	})
+
+	t.Run("move", func(t *testing.T) {
+		spec, _ := parseTreeSpec(`
+issues:
+  - id: launch
+    children: []
+  - number: 45
+    children:
+      - number: 11
+`)
+		st := appliedState()
+		st.numbers["#11"] = 11
+		changes, err := diffSpec(spec, st)
+		assert.NoError(t, err)
+		// #11 is placed under #45, so it is moved rather than unlinked
+		assert.Equal(t, []string{"unlink #45", "link #11"}, specActions(changes))
+		assert.True(t, changes[1].move)
+		assert.Equal(t, "#45", changes[1].Parent)
+	})
+
+	t.Run("top-level parent", func(t *testing.T) {
+		spec, _ := parseTreeSpec("parent: 5\nissues:\n  - number: 45\n")
+		st := appliedState()
+		st.numbers["#5"] = 5
+		st.issues[5] = &specIssue{ID: "I5", Number: 5, Children: []IssueInfo{}}
+		changes, err := diffSpec(spec, st)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"link #45"}, specActions(changes))
+		assert.Equal(t, "#5", changes[0].Parent)
+	})
+
+	t.Run("missing title", func(t *testing.T) {
+		spec, _ := parseTreeSpec("issues:\n  - id: new\n")
+		_, err := diffSpec(spec, &specState{numbers: map[string]int{}, issues: map[int]*specIssue{}})
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "new does not exist yet and needs a title")
+		}
+	})
+}
+
+func TestFormatSpecChanges(t *testing.T) {
+	st := &specState{numbers: map[string]int{"launch": 10}}
+
+	assert.Equal(t, "No changes. The issues match the spec.\n", formatSpecChanges(nil, st))
+
+	changes := []SpecChange{
+		{Action: specCreate, Key: "backend", Title: "Backend", Parent: "launch"},
+		{Action: specUpdate, Key: "launch", Number: 10, Title: "Launch v2", Details: []string{"labels: +p1"}},
+		{Action: specLink, Key: "#45", Number: 45, Parent: "launch"},
+		{Action: specUnlink, Key: "#46", Number: 46, Parent: "launch"},
+		{Action: specReorder, Key: "launch", Number: 10, Details: []string{"backend", "#45"}},
+	}
+
+	expected := `  + create   backend: "Backend" (under #10)
+  ~ update   #10 Launch v2
+        labels: +p1
+  → link     #45 under #10
+  - unlink   #46 from #10
+  ↕ reorder  sub-issues of #10: backend, #45
+
+Plan: 1 to create, 1 to update, 1 to link, 1 to unlink, 1 to reorder.
+`
+	assert.Equal(t, expected, formatSpecChanges(changes, st))
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/spf13/cobra"
+)
+
+// Markers delimiting the generated checklist in a parent issue body
+const (
+	syncBlockStart = "<!-- sub-issues:start -->"
+	syncBlockEnd   = "<!-- sub-issues:end -->"
+)
+
+var syncBodyRepoFlag string
+
+var syncBodyCmd = &cobra.Command{
+	Use:   "sync-body <parent-issue>",
+	Short: "Keep a checklist of sub-issues in the parent issue body",
+	Long: `Write a checklist of the sub-issues into the body of the parent issue.
+
+The checklist shows every sub-issue with a checked box once it is closed, plus
+the overall progress. It is kept between the markers
+
+  ` + syncBlockStart + `
+  ` + syncBlockEnd + `
+
+and is appended to the body the first time. Run the command again to refresh it;
+everything outside the markers is left untouched.
+
+Examples:
+  # Add or refresh the checklist of issue #123
+  gh sub-issue sync-body 123
+
+  # Show the body update without sending it
+  gh sub-issue sync-body 123 --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSyncBody,
+}
+
+func init() {
+	rootCmd.AddCommand(syncBodyCmd)
+	syncBodyCmd.Flags().StringVarP(&syncBodyRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+}
+
+// renderSyncBlock renders the checklist block for the sub-issues of a parent in owner/repo
+func renderSyncBlock(result *ListResult, owner, repo string) string {
+	var block strings.Builder
+	block.WriteString(syncBlockStart + "\n")
+
+	closed := result.Total - result.OpenCount
+	percent := 0
+	if result.Total > 0 {
+		percent = closed * 100 / result.Total
+	}
+	block.WriteString(fmt.Sprintf("**Progress:** %d of %d sub-issues closed (%d%%)\n\n", closed, result.Total, percent))
+
+	for _, issue := range result.SubIssues {
+		check := " "
+		if issue.State == "closed" {
+			check = "x"
+		}
+
+		// Sub-issues from other repositories need a qualified reference
+		reference := fmt.Sprintf("#%d", issue.Number)
+		if ref, err := parseIssueURL(issue.URL); err == nil && (ref.Owner != owner || ref.Repo != repo) {
+			reference = fmt.Sprintf("%s/%s#%d", ref.Owner, ref.Repo, ref.Number)
+		}
+		block.WriteString(fmt.Sprintf("- [%s] %s\n", check, reference))
+	}
+
+	block.WriteString(syncBlockEnd)
+	return block.String()
+}
+
+// replaceSyncBlock replaces the block between the markers, or appends it when the body has none
+func replaceSyncBlock(body, block string) (string, error) {
+	start := strings.Index(body, syncBlockStart)
+	if start < 0 {
+		if strings.TrimSpace(body) == "" {
+			return block + "\n", nil
+		}
+		return strings.TrimRight(body, "\n") + "\n\n" + block + "\n", nil
+	}
+
+	end := strings.Index(body[start:], syncBlockEnd)
+	if end < 0 {
+		return "", fmt.Errorf("found %s without %s in the issue body", syncBlockStart, syncBlockEnd)
+	}
+	end += start + len(syncBlockEnd)
+
+	return body[:start] + block + body[end:], nil
+}
+
+func runSyncBody(cmd *cobra.Command, args []string) error {
+	// Get default repository if not specified
+	var defaultOwner, defaultRepo string
+	if syncBodyRepoFlag != "" {
+		parts := strings.Split(syncBodyRepoFlag, "/")
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid repository format: %s (expected OWNER/REPO)", syncBodyRepoFlag)
+		}
+		defaultOwner = parts[0]
+		defaultRepo = parts[1]
+	} else {
+		var err error
+		defaultOwner, defaultRepo, err = getDefaultRepo()
+		if err != nil {
+			return fmt.Errorf("no repository specified and could not determine from current directory: %w", err)
+		}
+	}
+
+	parentRef, err := parseIssueReference(args[0], defaultOwner, defaultRepo)
+	if err != nil {
+		return fmt.Errorf("invalid parent issue: %w", err)
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStderr(), "Getting sub-issues of #%d from %s/%s...\n",
+		parentRef.Number, parentRef.Owner, parentRef.Repo)
+	parent, err := getIssue(client, parentRef.Owner, parentRef.Repo, parentRef.Number)
+	if err != nil {
+		return err
+	}
+
+	// The checklist always covers every sub-issue
+	result, err := getSubIssues(client, parentRef.Owner, parentRef.Repo, parentRef.Number, 0, "all", "")
+	if err != nil {
+		return err
+	}
+
+	body, err := getIssueBody(client, parent.ID)
+	if err != nil {
+		return err
+	}
+	newBody, err := replaceSyncBlock(body, renderSyncBlock(result, parentRef.Owner, parentRef.Repo))
+	if err != nil {
+		return err
+	}
+
+	if newBody == body {
+		if dryRunFlag {
+			return writePlan(cmd.OutOrStdout(), !term.IsTerminal(os.Stdout))
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "✓ Checklist in #%d is already up to date\n", parentRef.Number)
+		return nil
+	}
+
+	if err := updateIssueBody(client, parent.ID, newBody); err != nil {
+		return err
+	}
+
+	if dryRunFlag {
+		return writePlan(cmd.OutOrStdout(), !term.IsTerminal(os.Stdout))
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "✓ Updated checklist in #%d (%d of %d sub-issues closed)\n",
+		parentRef.Number, result.Total-result.OpenCount, result.Total)
+	return nil
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderSyncBlock(t *testing.T) {
+	result := &ListResult{
+		SubIssues: []SubIssue{
+			{Number: 1, State: "closed", URL: "https://github.com/owner/repo/issues/1"},
+			{Number: 2, State: "open", URL: "https://github.com/owner/repo/issues/2"},
+			{Number: 7, State: "open", URL: "https://github.com/other/lib/issues/7"},
+		},
+		Total:     3,
+		OpenCount: 2,
+	}
+
+	expected := syncBlockStart + "\n" +
+		"**Progress:** 1 of 3 sub-issues closed (33%)\n\n" +
+		"- [x] #1\n" +
+		"- [ ] #2\n" +
+		"- [ ] other/lib#7\n" +
+		syncBlockEnd
+	assert.Equal(t, expected, renderSyncBlock(result, "owner", "repo"))
+}
+
+func TestRenderSyncBlockEmpty(t *testing.T) {
+	block := renderSyncBlock(&ListResult{}, "owner", "repo")
+	assert.Contains(t, block, "0 of 0 sub-issues closed (0%)")
+}
+
+func TestReplaceSyncBlock(t *testing.T) {
+	block := syncBlockStart + "\nnew\n" + syncBlockEnd
+
+	tests := []struct {
+		name     string
+		body     string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "empty body",
+			body:     "",
+			expected: block + "\n",
+		},
+		{
+			name:     "append to existing text",
+			body:     "Intro\n\n",
+			expected: "Intro\n\n" + block + "\n",
+		},
+		{
+			name:     "replace between markers",
+			body:     "Intro\n" + syncBlockStart + "\nold\n" + syncBlockEnd + "\nOutro",
+			expected: "Intro\n" + block + "\nOutro",
+		},
+		{
+			name:    "missing end marker",
+			body:    "Intro\n" + syncBlockStart + "\nold\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := replaceSyncBlock(tt.body, block)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
@@ -0,0 +1,325 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"gopkg.in/yaml.v3"
+)
+
+const issueTemplateDir = ".github/ISSUE_TEMPLATE"
+
+// IssueTemplate is a Markdown issue template or an issue form
+type IssueTemplate struct {
+	File      string
+	Name      string
+	About     string
+	Title     string
+	Labels    []string
+	Assignees []string
+	Body      string
+}
+
+// stringList accepts both "a, b" and [a, b] in template front matter
+type stringList []string
+
+func (l *stringList) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*l = nil
+		for _, item := range strings.Split(value.Value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				*l = append(*l, item)
+			}
+		}
+		return nil
+	}
+
+	var items []string
+	if err := value.Decode(&items); err != nil {
+		return err
+	}
+	*l = items
+	return nil
+}
+
+// templateHeader is the metadata shared by Markdown templates and issue forms
+type templateHeader struct {
+	Name        string     `yaml:"name"`
+	About       string     `yaml:"about"`
+	Description string     `yaml:"description"`
+	Title       string     `yaml:"title"`
+	Labels      stringList `yaml:"labels"`
+	Assignees   stringList `yaml:"assignees"`
+}
+
+// formElement is an element of an issue form body
+type formElement struct {
+	Type       string `yaml:"type"`
+	ID         string `yaml:"id"`
+	Attributes struct {
+		Label   string        `yaml:"label"`
+		Value   string        `yaml:"value"`
+		Render  string        `yaml:"render"`
+		Options []interface{} `yaml:"options"`
+		Default *int          `yaml:"default"`
+	} `yaml:"attributes"`
+}
+
+// parseMarkdownTemplate parses a Markdown template with optional YAML front matter
+func parseMarkdownTemplate(file, content string) (*IssueTemplate, error) {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	tmpl := &IssueTemplate{File: file, Body: content}
+
+	if strings.HasPrefix(content, "---\n") {
+		frontMatter, body, ok := strings.Cut("\n"+content[4:], "\n---")
+		if !ok {
+			return nil, fmt.Errorf("%s: unterminated front matter", file)
+		}
+
+		var header templateHeader
+		if err := yaml.Unmarshal([]byte(frontMatter), &header); err != nil {
+			return nil, fmt.Errorf("%s: invalid front matter: %w", file, err)
+		}
+		tmpl.Name = header.Name
+		tmpl.About = header.About
+		tmpl.Title = header.Title
+		tmpl.Labels = header.Labels
+		tmpl.Assignees = header.Assignees
+		tmpl.Body = strings.TrimLeft(body, "\n")
+	}
+
+	if tmpl.Name == "" {
+		tmpl.Name = strings.TrimSuffix(file, filepath.Ext(file))
+	}
+	return tmpl, nil
+}
+
+// parseIssueForm parses a YAML issue form and renders its fields into a body
+func parseIssueForm(file, content string) (*IssueTemplate, error) {
+	var form struct {
+		templateHeader `yaml:",inline"`
+		Body           []formElement `yaml:"body"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &form); err != nil {
+		return nil, fmt.Errorf("%s: invalid issue form: %w", file, err)
+	}
+
+	tmpl := &IssueTemplate{
+		File:      file,
+		Name:      form.Name,
+		About:     form.Description,
+		Title:     form.Title,
+		Labels:    form.Labels,
+		Assignees: form.Assignees,
+		Body:      renderIssueForm(form.Body),
+	}
+	if tmpl.Name == "" {
+		tmpl.Name = strings.TrimSuffix(file, filepath.Ext(file))
+	}
+	return tmpl, nil
+}
+
+// renderIssueForm renders form fields the way GitHub renders a submitted issue form
+func renderIssueForm(elements []formElement) string {
+	var sections []string
+
+	for _, element := range elements {
+		attrs := element.Attributes
+		var value string
+
+		switch element.Type {
+		case "markdown":
+			// Markdown elements are only shown in the form, not in the issue
+			continue
+		case "input", "textarea":
+			value = attrs.Value
+			if value != "" && attrs.Render != "" {
+				value = fmt.Sprintf("```%s\n%s\n```", attrs.Render, value)
+			}
+		case "dropdown":
+			if attrs.Default != nil && *attrs.Default >= 0 && *attrs.Default < len(attrs.Options) {
+				value = fmt.Sprint(attrs.Options[*attrs.Default])
+			}
+		case "checkboxes":
+			var boxes []string
+			for _, option := range attrs.Options {
+				label := option
+				if m, ok := option.(map[string]interface{}); ok {
+					label = m["label"]
+				}
+				boxes = append(boxes, fmt.Sprintf("- [ ] %v", label))
+			}
+			value = strings.Join(boxes, "\n")
+		default:
+			continue
+		}
+
+		if strings.TrimSpace(value) == "" {
+			value = "_No response_"
+		}
+		sections = append(sections, fmt.Sprintf("### %s\n\n%s", attrs.Label, strings.TrimRight(value, "\n")))
+	}
+
+	return strings.Join(sections, "\n\n")
+}
+
+// parseTemplateFile parses a template file based on its extension
+func parseTemplateFile(file, content string) (*IssueTemplate, error) {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".md":
+		return parseMarkdownTemplate(file, content)
+	case ".yml", ".yaml":
+		return parseIssueForm(file, content)
+	}
+	return nil, nil
+}
+
+// isTemplateFile reports whether a file in the template directory is a template
+func isTemplateFile(name string) bool {
+	base := strings.ToLower(name)
+	if base == "config.yml" || base == "config.yaml" {
+		return false
+	}
+	ext := filepath.Ext(base)
+	return ext == ".md" || ext == ".yml" || ext == ".yaml"
+}
+
+// loadLocalTemplates reads templates from the local checkout, if there is one
+func loadLocalTemplates() ([]*IssueTemplate, error) {
+	output, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return nil, nil
+	}
+
+	dir := filepath.Join(strings.TrimSpace(string(output)), filepath.FromSlash(issueTemplateDir))
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil
+	}
+
+	var templates []*IssueTemplate
+	for _, entry := range entries {
+		if entry.IsDir() || !isTemplateFile(entry.Name()) {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template %s: %w", entry.Name(), err)
+		}
+		tmpl, err := parseTemplateFile(entry.Name(), string(content))
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, tmpl)
+	}
+
+	return templates, nil
+}
+
+// loadRemoteTemplates reads templates from the repository using the contents API
+func loadRemoteTemplates(client *api.RESTClient, owner, repo string) ([]*IssueTemplate, error) {
+	var entries []struct {
+		Name string `json:"name"`
+		Path string `json:"path"`
+		Type string `json:"type"`
+	}
+
+	err := client.Get(fmt.Sprintf("repos/%s/%s/contents/%s", owner, repo, issueTemplateDir), &entries)
+	if err != nil {
+		if strings.Contains(err.Error(), "404") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list issue templates: %w", err)
+	}
+
+	var templates []*IssueTemplate
+	for _, entry := range entries {
+		if entry.Type != "file" || !isTemplateFile(entry.Name) {
+			continue
+		}
+
+		var file struct {
+			Content  string `json:"content"`
+			Encoding string `json:"encoding"`
+		}
+		if err := client.Get(fmt.Sprintf("repos/%s/%s/contents/%s", owner, repo, entry.Path), &file); err != nil {
+			return nil, fmt.Errorf("failed to get template %s: %w", entry.Name, err)
+		}
+
+		content := file.Content
+		if file.Encoding == "base64" {
+			decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(content, "\n", ""))
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode template %s: %w", entry.Name, err)
+			}
+			content = string(decoded)
+		}
+
+		tmpl, err := parseTemplateFile(entry.Name, content)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, tmpl)
+	}
+
+	return templates, nil
+}
+
+// loadIssueTemplate loads a template by name, preferring the local checkout when it is the target repository
+func loadIssueTemplate(owner, repo, name string, preferLocal bool) (*IssueTemplate, error) {
+	var templates []*IssueTemplate
+	var err error
+
+	if preferLocal {
+		templates, err = loadLocalTemplates()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(templates) == 0 {
+		client, err := api.NewRESTClient(api.ClientOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create GitHub client: %w", err)
+		}
+		templates, err = loadRemoteTemplates(client, owner, repo)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return findTemplate(templates, name)
+}
+
+// findTemplate finds a template by name or file name (with or without extension)
+func findTemplate(templates []*IssueTemplate, name string) (*IssueTemplate, error) {
+	var names []string
+	for _, tmpl := range templates {
+		base := strings.TrimSuffix(tmpl.File, filepath.Ext(tmpl.File))
+		if strings.EqualFold(tmpl.Name, name) || strings.EqualFold(tmpl.File, name) || strings.EqualFold(base, name) {
+			return tmpl, nil
+		}
+		names = append(names, tmpl.Name)
+	}
+
+	if len(names) == 0 {
+		return nil, fmt.Errorf("template '%s' not found: repository has no issue templates", name)
+	}
+	return nil, fmt.Errorf("template '%s' not found (available: %s)", name, strings.Join(names, ", "))
+}
+
+// applyTemplateTitle adds the template title prefix unless the title already has it
+func applyTemplateTitle(prefix, title string) string {
+	if prefix == "" || strings.HasPrefix(title, prefix) {
+		return title
+	}
+	if title == "" {
+		return prefix
+	}
+	return prefix + title
+}
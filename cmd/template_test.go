@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMarkdownTemplate(t *testing.T) {
+	content := "---\nname: Bug report\nabout: Report a problem\ntitle: \"[Bug]: \"\nlabels: bug, triage\nassignees:\n  - alice\n---\n\n## Steps to reproduce\n\n1.\n"
+
+	tmpl, err := parseMarkdownTemplate("bug_report.md", content)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bug report", tmpl.Name)
+	assert.Equal(t, "Report a problem", tmpl.About)
+	assert.Equal(t, "[Bug]: ", tmpl.Title)
+	assert.Equal(t, []string{"bug", "triage"}, tmpl.Labels)
+	assert.Equal(t, []string{"alice"}, tmpl.Assignees)
+	assert.Equal(t, "## Steps to reproduce\n\n1.\n", tmpl.Body)
+}
+
+func TestParseMarkdownTemplateWithoutFrontMatter(t *testing.T) {
+	tmpl, err := parseMarkdownTemplate("task.md", "Just a body\n")
+	assert.NoError(t, err)
+	assert.Equal(t, "task", tmpl.Name)
+	assert.Equal(t, "Just a body\n", tmpl.Body)
+	assert.Empty(t, tmpl.Labels)
+}
+
+func TestParseMarkdownTemplateUnterminated(t *testing.T) {
+	_, err := parseMarkdownTemplate("broken.md", "---\nname: Broken\n")
+	assert.Error(t, err)
+}
+
+func TestParseIssueForm(t *testing.T) {
+	content := `name: Feature request
+description: Suggest an idea
+title: "[Feature]: "
+labels: ["enhancement"]
+assignees: bob
+body:
+  - type: markdown
+    attributes:
+      value: Thanks for taking the time!
+  - type: textarea
+    id: problem
+    attributes:
+      label: Problem
+  - type: textarea
+    id: logs
+    attributes:
+      label: Logs
+      value: paste here
+      render: shell
+  - type: input
+    id: version
+    attributes:
+      label: Version
+      value: "1.0"
+  - type: dropdown
+    id: area
+    attributes:
+      label: Area
+      options: [CLI, API]
+      default: 1
+  - type: checkboxes
+    id: terms
+    attributes:
+      label: Checklist
+      options:
+        - label: I searched existing issues
+        - label: I read the docs
+`
+
+	tmpl, err := parseIssueForm("feature.yml", content)
+	assert.NoError(t, err)
+	assert.Equal(t, "Feature request", tmpl.Name)
+	assert.Equal(t, "Suggest an idea", tmpl.About)
+	assert.Equal(t, "[Feature]: ", tmpl.Title)
+	assert.Equal(t, []string{"enhancement"}, tmpl.Labels)
+	assert.Equal(t, []string{"bob"}, tmpl.Assignees)
+
+	expected := "### Problem\n\n_No response_\n\n" +
+		"### Logs\n\n```shell\npaste here\n```\n\n" +
+		"### Version\n\n1.0\n\n" +
+		"### Area\n\nAPI\n\n" +
+		"### Checklist\n\n- [ ] I searched existing issues\n- [ ] I read the docs"
+	assert.Equal(t, expected, tmpl.Body)
+}
+
+func TestIsTemplateFile(t *testing.T) {
+	assert.True(t, isTemplateFile("bug_report.md"))
+	assert.True(t, isTemplateFile("feature.yml"))
+	assert.True(t, isTemplateFile("Feature.YAML"))
+	assert.False(t, isTemplateFile("config.yml"))
+	assert.False(t, isTemplateFile("README.txt"))
+}
+
+func TestFindTemplate(t *testing.T) {
+	templates := []*IssueTemplate{
+		{File: "bug_report.md", Name: "Bug report"},
+		{File: "feature.yml", Name: "Feature request"},
+	}
+
+	for _, name := range []string{"bug report", "bug_report", "bug_report.md"} {
+		tmpl, err := findTemplate(templates, name)
+		assert.NoError(t, err)
+		assert.Equal(t, "bug_report.md", tmpl.File)
+	}
+
+	_, err := findTemplate(templates, "question")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "available: Bug report, Feature request")
+	}
+
+	_, err = findTemplate(nil, "question")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "no issue templates")
+	}
+}
+
+func TestApplyTemplateTitle(t *testing.T) {
+	assert.Equal(t, "[Bug]: Crash", applyTemplateTitle("[Bug]: ", "Crash"))
+	assert.Equal(t, "[Bug]: Crash", applyTemplateTitle("[Bug]: ", "[Bug]: Crash"))
+	assert.Equal(t, "[Bug]: ", applyTemplateTitle("[Bug]: ", ""))
+	assert.Equal(t, "Crash", applyTemplateTitle("", "Crash"))
+}
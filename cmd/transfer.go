@@ -0,0 +1,385 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/term"
+	"github.com/spf13/cobra"
+)
+
+var (
+	transferToFlag        string
+	transferRecursiveFlag bool
+	transferRepoFlag      string
+	transferForceFlag     bool
+	transferJSONFlag      bool
+)
+
+var transferCmd = &cobra.Command{
+	Use:   "transfer <issue>",
+	Short: "Transfer an issue to another repository, keeping its hierarchy",
+	Long: `Transfer an issue to another repository of the same owner, then check that it
+is still a sub-issue of its parent at the same position and still has all of its
+sub-issues in order, re-linking and reordering where needed.
+
+With --recursive, every sub-issue of the hierarchy is transferred as well, after
+listing the issues and asking for confirmation unless --force is given. Labels
+missing in the target repository are created.
+
+Examples:
+  # Move an issue, keeping it under its parent
+  gh sub-issue transfer 123 --to owner/other-repo
+
+  # Move an epic with all of its sub-issues
+  gh sub-issue transfer 123 --to owner/other-repo --recursive
+
+  # Report old and new URLs as JSON
+  gh sub-issue transfer 123 --to owner/other-repo --recursive --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTransfer,
+}
+
+func init() {
+	rootCmd.AddCommand(transferCmd)
+	transferCmd.Flags().StringVar(&transferToFlag, "to", "", "Repository to transfer to, in OWNER/REPO format (required)")
+	transferCmd.Flags().BoolVarP(&transferRecursiveFlag, "recursive", "r", false, "Also transfer all sub-issues")
+	transferCmd.Flags().StringVarP(&transferRepoFlag, "repo", "R", "", "Repository in OWNER/REPO format")
+	transferCmd.Flags().BoolVarP(&transferForceFlag, "force", "f", false, "Skip confirmation prompt for --recursive")
+	transferCmd.Flags().BoolVar(&transferJSONFlag, "json", false, "Output results as JSON")
+	transferCmd.MarkFlagRequired("to")
+}
+
+// TransferResult describes a transferred issue and the links repaired afterwards
+type TransferResult struct {
+	Title   string      `json:"title"`
+	Old     IssueResult `json:"old"`
+	New     IssueResult `json:"new"`
+	Repairs []string    `json:"repairs,omitempty"`
+}
+
+// transferNodes returns the issues to transfer, parents first. Issues already in the
+// target repository are left out.
+func transferNodes(tree *IssueNode, target string, recursive bool) []*IssueNode {
+	var nodes []*IssueNode
+	tree.Walk(func(node *IssueNode, depth int) {
+		if depth > 0 && !recursive {
+			return
+		}
+		if !strings.EqualFold(node.Repository, target) {
+			nodes = append(nodes, node)
+		}
+	})
+	return nodes
+}
+
+// childRepairs compares the expected sub-issues of an issue, by node ID and in order,
+// with its current sub-issues. It returns the sub-issues to re-link and whether the
+// order must be restored once they are linked.
+func childRepairs(expected []string, current []IssueInfo) ([]string, bool) {
+	linked := make(map[string]bool)
+	for _, child := range current {
+		linked[child.ID] = true
+	}
+	wanted := make(map[string]bool)
+	for _, id := range expected {
+		wanted[id] = true
+	}
+
+	// Re-linked sub-issues are appended after the current ones
+	var missing, predicted []string
+	for _, child := range current {
+		if wanted[child.ID] {
+			predicted = append(predicted, child.ID)
+		}
+	}
+	for _, id := range expected {
+		if !linked[id] {
+			missing = append(missing, id)
+			predicted = append(predicted, id)
+		}
+	}
+
+	return missing, strings.Join(predicted, ",") != strings.Join(expected, ",")
+}
+
+// restoreChildren makes the expected issues sub-issues of parentID, in order. Other
+// sub-issues are left alone. It returns a description of each repair.
+func restoreChildren(client *api.GraphQLClient, parentID string, expected []string) ([]string, error) {
+	current, err := getChildIssues(client, parentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var repairs []string
+	missing, reorder := childRepairs(expected, current)
+	for _, id := range missing {
+		if _, _, err := addSubIssue(client, parentID, id); err != nil {
+			return repairs, err
+		}
+	}
+	if len(missing) > 0 {
+		repairs = append(repairs, fmt.Sprintf("re-linked %d sub-issue(s)", len(missing)))
+	}
+
+	if reorder {
+		for i := 1; i < len(expected); i++ {
+			if err := reprioritizeSubIssue(client, parentID, expected[i], expected[i-1]); err != nil {
+				return repairs, err
+			}
+		}
+		repairs = append(repairs, "restored sub-issue order")
+	}
+	return repairs, nil
+}
+
+// formatTransferResults formats the old and new URLs of the transferred issues
+func formatTransferResults(results []*TransferResult) string {
+	var output strings.Builder
+
+	w := tabwriter.NewWriter(&output, 0, 0, 2, ' ', 0)
+	for _, result := range results {
+		to := result.New.URL
+		if result.New.Status == statusFailed {
+			to = fmt.Sprintf("failed (%s)", result.New.Error)
+		}
+		line := fmt.Sprintf("%s\t→\t%s", result.Old.URL, to)
+		if len(result.Repairs) > 0 {
+			line += fmt.Sprintf("\t(%s)", strings.Join(result.Repairs, ", "))
+		}
+		fmt.Fprintln(w, line)
+	}
+	w.Flush()
+
+	return output.String()
+}
+
+// transferIssue transfers an issue to another repository, creating missing labels
+func transferIssue(client *api.GraphQLClient, issueID, repositoryID string) (*IssueInfo, error) {
+	mutation := `
+		mutation($issueId: ID!, $repositoryId: ID!) {
+			transferIssue(input: {
+				issueId: $issueId,
+				repositoryId: $repositoryId,
+				createLabelsIfMissing: true
+			}) {
+				issue {
+					id
+					number
+					title
+					url
+					state
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"issueId":      issueID,
+		"repositoryId": repositoryID,
+	}
+
+	var response struct {
+		TransferIssue struct {
+			Issue IssueInfo `json:"issue"`
+		} `json:"transferIssue"`
+	}
+
+	err := doMutation(client, "transferIssue", "Transfer issue", mutation, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transfer issue: %w", err)
+	}
+
+	issue := response.TransferIssue.Issue
+	issue.State = strings.ToLower(issue.State)
+	return &issue, nil
+}
+
+// repairTransfer restores the parent link and sibling position of the transferred root and
+// the sub-issues of every transferred issue. ids maps old node IDs to new ones.
+func repairTransfer(w io.Writer, client *api.GraphQLClient, tree *IssueNode, parent *IssueInfo, siblings []IssueInfo, ids map[string]string, results map[string]*TransferResult) error {
+	newID := func(id string) string {
+		if mapped, ok := ids[id]; ok {
+			return mapped
+		}
+		return id
+	}
+
+	if parent != nil {
+		if result, ok := results[tree.ID]; ok {
+			var expected []string
+			for _, sibling := range siblings {
+				expected = append(expected, newID(sibling.ID))
+			}
+			fmt.Fprintf(w, "Checking link to parent #%d...\n", parent.Number)
+			repairs, err := restoreChildren(client, parent.ID, expected)
+			for _, repair := range repairs {
+				result.Repairs = append(result.Repairs, fmt.Sprintf("parent #%d: %s", parent.Number, repair))
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	var err error
+	tree.Walk(func(node *IssueNode, depth int) {
+		result, ok := results[node.ID]
+		if err != nil || !ok || len(node.Children) == 0 {
+			return
+		}
+		var expected []string
+		for _, child := range node.Children {
+			expected = append(expected, newID(child.ID))
+		}
+		fmt.Fprintf(w, "Checking sub-issues of %s...\n", result.New.URL)
+		var repairs []string
+		repairs, err = restoreChildren(client, newID(node.ID), expected)
+		result.Repairs = append(result.Repairs, repairs...)
+	})
+	return err
+}
+
+func runTransfer(cmd *cobra.Command, args []string) error {
+	parts := strings.Split(transferToFlag, "/")
+	if len(parts) != 2 {
+		return fmt.Errorf("invalid repository format: %s (expected OWNER/REPO)", transferToFlag)
+	}
+	toOwner, toRepo := parts[0], parts[1]
+	target := toOwner + "/" + toRepo
+
+	// Get default repository if not specified
+	var defaultOwner, defaultRepo string
+	if transferRepoFlag != "" {
+		parts := strings.Split(transferRepoFlag, "/")
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid repository format: %s (expected OWNER/REPO)", transferRepoFlag)
+		}
+		defaultOwner = parts[0]
+		defaultRepo = parts[1]
+	} else {
+		var err error
+		defaultOwner, defaultRepo, err = getDefaultRepo()
+		if err != nil {
+			return fmt.Errorf("no repository specified and could not determine from current directory: %w", err)
+		}
+	}
+
+	ref, err := parseIssueReference(args[0], defaultOwner, defaultRepo)
+	if err != nil {
+		return fmt.Errorf("invalid issue: %w", err)
+	}
+	if strings.EqualFold(ref.Owner+"/"+ref.Repo, target) {
+		return fmt.Errorf("issue #%d is already in %s", ref.Number, target)
+	}
+
+	client, err := api.NewGraphQLClient(api.ClientOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to create API client: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStderr(), "Getting sub-issue tree of #%d from %s/%s...\n", ref.Number, ref.Owner, ref.Repo)
+	tree, err := getIssueTree(client, ref.Owner, ref.Repo, ref.Number, maxTreeDepth)
+	if err != nil {
+		return err
+	}
+
+	nodes := transferNodes(tree, target, transferRecursiveFlag)
+
+	// Get confirmation if not forced (nothing is changed in dry-run mode)
+	if transferRecursiveFlag && len(nodes) > 0 && !transferForceFlag && !dryRunFlag {
+		fmt.Fprintf(cmd.OutOrStderr(), "\nIssues to transfer to %s:\n", target)
+		for _, node := range nodes {
+			fmt.Fprintf(cmd.OutOrStderr(), "  %s#%d %s\n", node.Repository, node.Number, node.Title)
+		}
+		fmt.Fprintf(cmd.OutOrStderr(), "\nAre you sure you want to transfer %d issue(s)? (y/N): ", len(nodes))
+		var response string
+		fmt.Scanln(&response)
+		if strings.ToLower(response) != "y" && strings.ToLower(response) != "yes" {
+			fmt.Fprintln(cmd.OutOrStderr(), "Transfer cancelled")
+			return nil
+		}
+	}
+
+	// Remember where the issue sits under its parent
+	parent, err := getIssueParent(client, tree.ID)
+	if err != nil {
+		return err
+	}
+	var siblings []IssueInfo
+	if parent != nil {
+		siblings, err = getChildIssues(client, parent.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	repoID, err := getRepositoryID(client, toOwner, toRepo)
+	if err != nil {
+		return err
+	}
+
+	ids := make(map[string]string)
+	results := make(map[string]*TransferResult)
+	var ordered []*TransferResult
+	var errors []error
+
+	for _, node := range nodes {
+		result := &TransferResult{
+			Title: node.Title,
+			Old:   IssueResult{Number: node.Number, URL: node.URL, ID: node.ID, Repository: node.Repository},
+			New:   IssueResult{Repository: target},
+		}
+		ordered = append(ordered, result)
+
+		fmt.Fprintf(cmd.OutOrStderr(), "Transferring %s#%d to %s...\n", node.Repository, node.Number, target)
+		issue, err := transferIssue(client, node.ID, repoID)
+		if err != nil {
+			errors = append(errors, fmt.Errorf("%s#%d: %w", node.Repository, node.Number, err))
+			result.New.Status = statusFailed
+			result.New.Error = err.Error()
+			continue
+		}
+		result.New.Number, result.New.URL, result.New.ID = issue.Number, issue.URL, issue.ID
+		result.New.Title = issue.Title
+		result.New.Status = statusTransferred
+		ids[node.ID] = issue.ID
+		results[node.ID] = result
+	}
+
+	if dryRunFlag {
+		return writePlan(cmd.OutOrStdout(), transferJSONFlag || !term.IsTerminal(os.Stdout))
+	}
+
+	if err := repairTransfer(cmd.OutOrStderr(), client, tree, parent, siblings, ids, results); err != nil {
+		errors = append(errors, fmt.Errorf("restoring links: %w", err))
+	}
+
+	if transferJSONFlag {
+		if ordered == nil {
+			ordered = []*TransferResult{}
+		}
+		jsonBytes, err := json.MarshalIndent(ordered, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to format JSON: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(jsonBytes))
+	} else {
+		fmt.Fprint(cmd.OutOrStdout(), formatTransferResults(ordered))
+	}
+
+	// Display errors if any
+	if len(errors) > 0 {
+		fmt.Fprintln(cmd.OutOrStderr(), "\nErrors encountered:")
+		for _, err := range errors {
+			fmt.Fprintf(cmd.OutOrStderr(), "  - %v\n", err)
+		}
+		return fmt.Errorf("failed to transfer %d issue(s) or their links", len(errors))
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransferNodes(t *testing.T) {
+	numbers := func(nodes []*IssueNode) []int {
+		var result []int
+		for _, node := range nodes {
+			result = append(result, node.Number)
+		}
+		return result
+	}
+
+	assert.Equal(t, []int{1}, numbers(transferNodes(testTree(), "owner/new", false)))
+	assert.Equal(t, []int{1, 2, 4, 5, 3}, numbers(transferNodes(testTree(), "owner/new", true)))
+	// Issues already in the target repository stay where they are
+	assert.Equal(t, []int{1, 2, 4, 3}, numbers(transferNodes(testTree(), "Other/Lib", true)))
+}
+
+func TestChildRepairs(t *testing.T) {
+	current := func(ids ...string) []IssueInfo {
+		var children []IssueInfo
+		for _, id := range ids {
+			children = append(children, IssueInfo{ID: id})
+		}
+		return children
+	}
+
+	tests := []struct {
+		name        string
+		expected    []string
+		current     []IssueInfo
+		wantMissing []string
+		wantReorder bool
+	}{
+		{"intact", []string{"a", "b", "c"}, current("a", "b", "c"), nil, false},
+		{"last unlinked", []string{"a", "b", "c"}, current("a", "b"), []string{"c"}, false},
+		{"middle unlinked", []string{"a", "b", "c"}, current("a", "c"), []string{"b"}, true},
+		{"reordered", []string{"a", "b"}, current("b", "a"), nil, true},
+		{"extra sub-issues", []string{"a", "b"}, current("x", "a", "y", "b"), nil, false},
+		{"none linked", []string{"a", "b"}, nil, []string{"a", "b"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			missing, reorder := childRepairs(tt.expected, tt.current)
+			assert.Equal(t, tt.wantMissing, missing)
+			assert.Equal(t, tt.wantReorder, reorder)
+		})
+	}
+}
+
+func TestFormatTransferResults(t *testing.T) {
+	results := []*TransferResult{
+		{
+			Old:     IssueResult{URL: "https://github.com/owner/repo/issues/1"},
+			New:     IssueResult{URL: "https://github.com/owner/new/issues/7", Status: statusTransferred},
+			Repairs: []string{"parent #3: re-linked 1 sub-issue(s)", "restored sub-issue order"},
+		},
+		{
+			Old: IssueResult{URL: "https://github.com/owner/repo/issues/2"},
+			New: IssueResult{Status: statusFailed, Error: "boom"},
+		},
+	}
+
+	expected := "https://github.com/owner/repo/issues/1  →  https://github.com/owner/new/issues/7  (parent #3: re-linked 1 sub-issue(s), restored sub-issue order)\n" +
+		"https://github.com/owner/repo/issues/2  →  failed (boom)\n"
+	assert.Equal(t, expected, formatTransferResults(results))
+}
@@ -0,0 +1,592 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// maxTreeDepth is the deepest issue hierarchy GitHub allows
+const maxTreeDepth = 8
+
+// issueNodeFields are the fields fetched for every issue of a tree
+const issueNodeFields = `
+	id
+	number
+	title
+	url
+	state
+	stateReason
+	updatedAt
+	repository {
+		nameWithOwner
+	}
+	labels(first: 20) {
+		totalCount
+		nodes {
+			name
+		}
+	}
+	assignees(first: 10) {
+		totalCount
+		nodes {
+			login
+		}
+	}
+	milestone {
+		title
+		dueOn
+	}
+	issueType {
+		name
+	}
+	subIssuesSummary {
+		total
+	}`
+
+// TreeMilestone is the milestone of an issue in a tree
+type TreeMilestone struct {
+	Title string `json:"title"`
+	DueOn string `json:"dueOn,omitempty"`
+}
+
+// IssueNode is an issue with its sub-issues
+type IssueNode struct {
+	ID          string         `json:"id"`
+	Number      int            `json:"number"`
+	Title       string         `json:"title"`
+	URL         string         `json:"url"`
+	State       string         `json:"state"`
+	StateReason string         `json:"stateReason,omitempty"`
+	UpdatedAt   string         `json:"updatedAt"`
+	Repository  string         `json:"repository"`
+	Labels      []string       `json:"labels"`
+	Assignees   []string       `json:"assignees"`
+	Milestone   *TreeMilestone `json:"milestone,omitempty"`
+	Type        string         `json:"type,omitempty"`
+	Body        string         `json:"body,omitempty"`
+	Children    []*IssueNode   `json:"children"`
+
+	// subIssueCount is the number of sub-issues reported by GitHub, fetched or not
+	subIssueCount int
+
+	// beyondDepth is set when the sub-issues were not fetched because of the depth limit
+	beyondDepth bool
+
+	// labelCount and assigneeCount are the totals reported by GitHub, fetched or not
+	labelCount    int
+	assigneeCount int
+}
+
+// issueNodeResponse is the GraphQL shape of issueNodeFields
+type issueNodeResponse struct {
+	ID          string `json:"id"`
+	Number      int    `json:"number"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+	State       string `json:"state"`
+	StateReason string `json:"stateReason"`
+	UpdatedAt   string `json:"updatedAt"`
+	Repository  struct {
+		NameWithOwner string `json:"nameWithOwner"`
+	} `json:"repository"`
+	Labels struct {
+		TotalCount int `json:"totalCount"`
+		Nodes      []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"labels"`
+	Assignees struct {
+		TotalCount int `json:"totalCount"`
+		Nodes      []struct {
+			Login string `json:"login"`
+		} `json:"nodes"`
+	} `json:"assignees"`
+	Milestone *TreeMilestone `json:"milestone"`
+	IssueType *struct {
+		Name string `json:"name"`
+	} `json:"issueType"`
+	SubIssuesSummary struct {
+		Total int `json:"total"`
+	} `json:"subIssuesSummary"`
+}
+
+// toNode converts a GraphQL response into an IssueNode without children
+func (r issueNodeResponse) toNode() *IssueNode {
+	node := &IssueNode{
+		ID:            r.ID,
+		Number:        r.Number,
+		Title:         r.Title,
+		URL:           r.URL,
+		State:         strings.ToLower(r.State),
+		StateReason:   strings.ToLower(r.StateReason),
+		UpdatedAt:     r.UpdatedAt,
+		Repository:    r.Repository.NameWithOwner,
+		Labels:        []string{},
+		Assignees:     []string{},
+		Milestone:     r.Milestone,
+		Children:      []*IssueNode{},
+		subIssueCount: r.SubIssuesSummary.Total,
+		labelCount:    r.Labels.TotalCount,
+		assigneeCount: r.Assignees.TotalCount,
+	}
+	for _, label := range r.Labels.Nodes {
+		node.Labels = append(node.Labels, label.Name)
+	}
+	for _, assignee := range r.Assignees.Nodes {
+		node.Assignees = append(node.Assignees, assignee.Login)
+	}
+	if r.IssueType != nil {
+		node.Type = r.IssueType.Name
+	}
+	return node
+}
+
+// getIssueTree fetches an issue and its sub-issues down to maxDepth levels
+func getIssueTree(client *api.GraphQLClient, owner, repo string, number, maxDepth int) (*IssueNode, error) {
+	query := `
+		query($owner: String!, $repo: String!, $number: Int!) {
+			repository(owner: $owner, name: $repo) {
+				issue(number: $number) {` + issueNodeFields + `
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"owner":  owner,
+		"repo":   repo,
+		"number": number,
+	}
+
+	var response struct {
+		Repository struct {
+			Issue *issueNodeResponse `json:"issue"`
+		} `json:"repository"`
+	}
+
+	err := client.Do(query, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get issue #%d: %w", number, err)
+	}
+	if response.Repository.Issue == nil {
+		return nil, fmt.Errorf("issue #%d not found in %s/%s", number, owner, repo)
+	}
+
+	root := response.Repository.Issue.toNode()
+	if err := fetchSubTree(client, root, 1, maxDepth); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// fetchSubTree fetches the sub-issues of node recursively; depth is the level of its children
+func fetchSubTree(client *api.GraphQLClient, node *IssueNode, depth, maxDepth int) error {
+	if node.subIssueCount == 0 {
+		return nil
+	}
+	if depth > maxDepth {
+		node.beyondDepth = true
+		return nil
+	}
+
+	query := `
+		query($id: ID!, $cursor: String) {
+			node(id: $id) {
+				... on Issue {
+					subIssues(first: 100, after: $cursor) {
+						nodes {` + issueNodeFields + `
+						}
+						pageInfo {
+							hasNextPage
+							endCursor
+						}
+					}
+				}
+			}
+		}`
+
+	var cursor *string
+	for {
+		variables := map[string]interface{}{
+			"id":     node.ID,
+			"cursor": cursor,
+		}
+
+		var response struct {
+			Node struct {
+				SubIssues struct {
+					Nodes    []issueNodeResponse `json:"nodes"`
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+				} `json:"subIssues"`
+			} `json:"node"`
+		}
+
+		err := client.Do(query, variables, &response)
+		if err != nil {
+			return fmt.Errorf("failed to get sub-issues of #%d: %w", node.Number, err)
+		}
+
+		for _, child := range response.Node.SubIssues.Nodes {
+			childNode := child.toNode()
+			if err := fetchSubTree(client, childNode, depth+1, maxDepth); err != nil {
+				return err
+			}
+			node.Children = append(node.Children, childNode)
+		}
+
+		if !response.Node.SubIssues.PageInfo.HasNextPage {
+			break
+		}
+		cursor = &response.Node.SubIssues.PageInfo.EndCursor
+	}
+	return nil
+}
+
+// getIssueAncestors fetches the parent chain of an issue, nearest parent first. It is
+// empty for a top-level issue.
+func getIssueAncestors(client *api.GraphQLClient, issueID string) ([]*IssueNode, error) {
+	query := `
+		query($id: ID!) {
+			node(id: $id) {
+				... on Issue {
+					parent {` + issueNodeFields + `
+					}
+				}
+			}
+		}`
+
+	var ancestors []*IssueNode
+	for len(ancestors) < maxTreeDepth {
+		variables := map[string]interface{}{
+			"id": issueID,
+		}
+
+		var response struct {
+			Node struct {
+				Parent *issueNodeResponse `json:"parent"`
+			} `json:"node"`
+		}
+
+		err := client.Do(query, variables, &response)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parent issue: %w", err)
+		}
+		if response.Node.Parent == nil {
+			break
+		}
+
+		parent := response.Node.Parent.toNode()
+		ancestors = append(ancestors, parent)
+		issueID = parent.ID
+	}
+	return ancestors, nil
+}
+
+// missingSubIssues describes the issues of the tree whose sub-issues were not fetched
+// because of the depth limit
+func (n *IssueNode) missingSubIssues() []string {
+	var missing []string
+	n.Walk(func(node *IssueNode, depth int) {
+		if node.beyondDepth {
+			missing = append(missing, fmt.Sprintf("%s#%d has %d sub-issues below the depth limit",
+				node.Repository, node.Number, node.subIssueCount))
+		}
+	})
+	return missing
+}
+
+// missingFields describes the issues of the tree whose labels or assignees were not all fetched
+func (n *IssueNode) missingFields() []string {
+	var missing []string
+	n.Walk(func(node *IssueNode, depth int) {
+		if node.labelCount > len(node.Labels) {
+			missing = append(missing, fmt.Sprintf("%s#%d has %d labels, only %d were fetched",
+				node.Repository, node.Number, node.labelCount, len(node.Labels)))
+		}
+		if node.assigneeCount > len(node.Assignees) {
+			missing = append(missing, fmt.Sprintf("%s#%d has %d assignees, only %d were fetched",
+				node.Repository, node.Number, node.assigneeCount, len(node.Assignees)))
+		}
+	})
+	return missing
+}
+
+// Walk calls fn for the node and every descendant, parents before children
+func (n *IssueNode) Walk(fn func(node *IssueNode, depth int)) {
+	n.walk(fn, 0)
+}
+
+func (n *IssueNode) walk(fn func(node *IssueNode, depth int), depth int) {
+	fn(n, depth)
+	for _, child := range n.Children {
+		child.walk(fn, depth+1)
+	}
+}
+
+// Leaves returns the issues of the tree without sub-issues
+func (n *IssueNode) Leaves() []*IssueNode {
+	var leaves []*IssueNode
+	n.Walk(func(node *IssueNode, depth int) {
+		if len(node.Children) == 0 {
+			leaves = append(leaves, node)
+		}
+	})
+	return leaves
+}
+
+// Reference returns "#N" for issues in owner/repo and "OWNER/REPO#N" otherwise
+func (n *IssueNode) Reference(owner, repo string) string {
+	if n.Repository == "" || strings.EqualFold(n.Repository, owner+"/"+repo) {
+		return fmt.Sprintf("#%d", n.Number)
+	}
+	return fmt.Sprintf("%s#%d", n.Repository, n.Number)
+}
+
+// repoIssueFields are the fields fetched when listing the issues of a repository
+const repoIssueFields = `
+	id
+	number
+	title
+	url
+	state
+	updatedAt
+	repository {
+		nameWithOwner
+	}
+	parent {
+		id
+	}
+	labels(first: 20) {
+		nodes {
+			name
+		}
+	}
+	assignees(first: 10) {
+		nodes {
+			login
+		}
+	}
+	milestone {
+		title
+	}
+	subIssuesSummary {
+		total
+		completed
+	}`
+
+// RepoIssue is an issue of a repository with its place in the hierarchy
+type RepoIssue struct {
+	IssueInfo
+	Repository string   `json:"repository"`
+	UpdatedAt  string   `json:"updatedAt"`
+	HasParent  bool     `json:"hasParent"`
+	Labels     []string `json:"labels"`
+	Assignees  []string `json:"assignees"`
+	Milestone  string   `json:"milestone,omitempty"`
+	Total      int      `json:"subIssues"`
+	Completed  int      `json:"completedSubIssues"`
+}
+
+// repoIssueResponse is the GraphQL shape of repoIssueFields
+type repoIssueResponse struct {
+	IssueInfo
+	UpdatedAt  string `json:"updatedAt"`
+	Repository struct {
+		NameWithOwner string `json:"nameWithOwner"`
+	} `json:"repository"`
+	Parent *struct {
+		ID string `json:"id"`
+	} `json:"parent"`
+	Labels struct {
+		Nodes []struct {
+			Name string `json:"name"`
+		} `json:"nodes"`
+	} `json:"labels"`
+	Assignees struct {
+		Nodes []struct {
+			Login string `json:"login"`
+		} `json:"nodes"`
+	} `json:"assignees"`
+	Milestone *struct {
+		Title string `json:"title"`
+	} `json:"milestone"`
+	SubIssuesSummary struct {
+		Total     int `json:"total"`
+		Completed int `json:"completed"`
+	} `json:"subIssuesSummary"`
+}
+
+// toRepoIssue converts a GraphQL response into a RepoIssue
+func (r repoIssueResponse) toRepoIssue() RepoIssue {
+	issue := RepoIssue{
+		IssueInfo:  r.IssueInfo,
+		Repository: r.Repository.NameWithOwner,
+		UpdatedAt:  r.UpdatedAt,
+		HasParent:  r.Parent != nil,
+		Labels:     []string{},
+		Assignees:  []string{},
+		Total:      r.SubIssuesSummary.Total,
+		Completed:  r.SubIssuesSummary.Completed,
+	}
+	issue.State = strings.ToLower(issue.State)
+	for _, label := range r.Labels.Nodes {
+		issue.Labels = append(issue.Labels, label.Name)
+	}
+	for _, assignee := range r.Assignees.Nodes {
+		issue.Assignees = append(issue.Assignees, assignee.Login)
+	}
+	if r.Milestone != nil {
+		issue.Milestone = r.Milestone.Title
+	}
+	return issue
+}
+
+// issueStates returns the GraphQL issue states for open, closed or all
+func issueStates(state string) []string {
+	if state == "all" {
+		return []string{"OPEN", "CLOSED"}
+	}
+	return []string{strings.ToUpper(state)}
+}
+
+// listRepoIssues pages through the issues of a repository in the given state
+// (open, closed or all)
+func listRepoIssues(client *api.GraphQLClient, owner, repo, state string) ([]RepoIssue, error) {
+	query := `
+		query($owner: String!, $repo: String!, $states: [IssueState!], $cursor: String) {
+			repository(owner: $owner, name: $repo) {
+				issues(first: 100, after: $cursor, states: $states) {
+					nodes {` + repoIssueFields + `
+					}
+					pageInfo {
+						hasNextPage
+						endCursor
+					}
+				}
+			}
+		}`
+
+	var issues []RepoIssue
+	var cursor *string
+
+	for {
+		variables := map[string]interface{}{
+			"owner":  owner,
+			"repo":   repo,
+			"states": issueStates(state),
+			"cursor": cursor,
+		}
+
+		var response struct {
+			Repository struct {
+				Issues struct {
+					Nodes    []repoIssueResponse `json:"nodes"`
+					PageInfo struct {
+						HasNextPage bool   `json:"hasNextPage"`
+						EndCursor   string `json:"endCursor"`
+					} `json:"pageInfo"`
+				} `json:"issues"`
+			} `json:"repository"`
+		}
+
+		err := client.Do(query, variables, &response)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list issues: %w", err)
+		}
+
+		for _, node := range response.Repository.Issues.Nodes {
+			issues = append(issues, node.toRepoIssue())
+		}
+
+		if !response.Repository.Issues.PageInfo.HasNextPage {
+			break
+		}
+		cursor = &response.Repository.Issues.PageInfo.EndCursor
+	}
+
+	return issues, nil
+}
+
+// listRootIssues returns the issues of a repository in the given state that have
+// sub-issues but no parent
+func listRootIssues(client *api.GraphQLClient, owner, repo, state string) ([]RepoIssue, error) {
+	issues, err := listRepoIssues(client, owner, repo, state)
+	if err != nil {
+		return nil, err
+	}
+	return filterRootIssues(issues), nil
+}
+
+// searchOrgRootIssues searches the issues of an organization in the given state that
+// have sub-issues but no parent
+func searchOrgRootIssues(client *api.GraphQLClient, org, state string) ([]RepoIssue, error) {
+	query := `
+		query($search: String!, $cursor: String) {
+			search(query: $search, type: ISSUE, first: 100, after: $cursor) {
+				nodes {
+					... on Issue {` + repoIssueFields + `
+					}
+				}
+				pageInfo {
+					hasNextPage
+					endCursor
+				}
+			}
+		}`
+
+	search := fmt.Sprintf("org:%s is:issue has:sub-issues no:parent-issue", org)
+	if state != "all" {
+		search += " state:" + state
+	}
+
+	var issues []RepoIssue
+	var cursor *string
+
+	for {
+		variables := map[string]interface{}{
+			"search": search,
+			"cursor": cursor,
+		}
+
+		var response struct {
+			Search struct {
+				Nodes    []repoIssueResponse `json:"nodes"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+			} `json:"search"`
+		}
+
+		err := client.Do(query, variables, &response)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search issues: %w", err)
+		}
+
+		for _, node := range response.Search.Nodes {
+			issues = append(issues, node.toRepoIssue())
+		}
+
+		if !response.Search.PageInfo.HasNextPage {
+			break
+		}
+		cursor = &response.Search.PageInfo.EndCursor
+	}
+
+	return filterRootIssues(issues), nil
+}
+
+// filterRootIssues returns the issues that have sub-issues but no parent
+func filterRootIssues(issues []RepoIssue) []RepoIssue {
+	var roots []RepoIssue
+	for _, issue := range issues {
+		if !issue.HasParent && issue.Total > 0 {
+			roots = append(roots, issue)
+		}
+	}
+	return roots
+}
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+// testTree builds #1 with children #2 (children #4, #5) and #3
+func testTree() *IssueNode {
+	return &IssueNode{Number: 1, State: "open", Repository: "owner/repo", Children: []*IssueNode{
+		{Number: 2, State: "open", Repository: "owner/repo", Children: []*IssueNode{
+			{Number: 4, State: "closed", Repository: "owner/repo"},
+			{Number: 5, State: "open", Repository: "other/lib"},
+		}},
+		{Number: 3, State: "closed", Repository: "owner/repo"},
+	}}
+}
+
+func TestIssueNodeWalk(t *testing.T) {
+	var visited []int
+	var depths []int
+	testTree().Walk(func(node *IssueNode, depth int) {
+		visited = append(visited, node.Number)
+		depths = append(depths, depth)
+	})
+
+	assert.Equal(t, []int{1, 2, 4, 5, 3}, visited)
+	assert.Equal(t, []int{0, 1, 2, 2, 1}, depths)
+}
+
+func TestIssueNodeLeaves(t *testing.T) {
+	var numbers []int
+	for _, leaf := range testTree().Leaves() {
+		numbers = append(numbers, leaf.Number)
+	}
+	assert.Equal(t, []int{4, 5, 3}, numbers)
+
+	single := &IssueNode{Number: 9}
+	assert.Equal(t, []*IssueNode{single}, single.Leaves())
+}
+
+func TestIssueNodeReference(t *testing.T) {
+	tree := testTree()
+	assert.Equal(t, "#1", tree.Reference("owner", "repo"))
+	assert.Equal(t, "other/lib#5", tree.Children[0].Children[1].Reference("owner", "repo"))
+	assert.Equal(t, "owner/repo#1", tree.Reference("other", "lib"))
+}
+
+func TestIssueNodeResponseToNode(t *testing.T) {
+	var response issueNodeResponse
+	response.Number = 7
+	response.State = "CLOSED"
+	response.StateReason = "NOT_PLANNED"
+	response.Repository.NameWithOwner = "owner/repo"
+	response.Labels.Nodes = append(response.Labels.Nodes, struct {
+		Name string `json:"name"`
+	}{Name: "epic"})
+	response.SubIssuesSummary.Total = 3
+
+	node := response.toNode()
+	assert.Equal(t, "closed", node.State)
+	assert.Equal(t, "not_planned", node.StateReason)
+	assert.Equal(t, []string{"epic"}, node.Labels)
+	assert.Equal(t, []string{}, node.Assignees)
+	assert.Equal(t, 3, node.subIssueCount)
+}
+
+func TestIssueNodeMissing(t *testing.T) {
+	tree := testTree()
+	assert.Empty(t, tree.missingSubIssues())
+	assert.Empty(t, tree.missingFields())
+
+	// Only sub-issues left out by the depth limit are missing
+	tree.subIssueCount = 2
+	tree.Children[0].subIssueCount = 120
+	tree.Children[1].subIssueCount = 1
+	tree.Children[1].beyondDepth = true
+	tree.Children[1].labelCount = 25
+	tree.Children[1].Labels = make([]string, 20)
+	tree.Children[1].assigneeCount = 1
+	tree.Children[1].Assignees = []string{"alice"}
+
+	assert.Equal(t, []string{
+		"owner/repo#3 has 1 sub-issues below the depth limit",
+	}, tree.missingSubIssues())
+	assert.Equal(t, []string{"owner/repo#3 has 25 labels, only 20 were fetched"}, tree.missingFields())
+}
+
+// graphQLPages answers GraphQL requests with its bodies in turn
+type graphQLPages struct {
+	bodies []string
+}
+
+func (p *graphQLPages) RoundTrip(req *http.Request) (*http.Response, error) {
+	body := p.bodies[0]
+	p.bodies = p.bodies[1:]
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+func TestFetchSubTreePages(t *testing.T) {
+	client, err := api.NewGraphQLClient(api.ClientOptions{
+		Host:      "github.com",
+		AuthToken: "token",
+		Transport: &graphQLPages{bodies: []string{
+			`{"data": {"node": {"subIssues": {"nodes": [{"id": "B", "number": 2}, {"id": "C", "number": 3}],
+				"pageInfo": {"hasNextPage": true, "endCursor": "c1"}}}}}`,
+			`{"data": {"node": {"subIssues": {"nodes": [{"id": "D", "number": 4, "subIssuesSummary": {"total": 1}}],
+				"pageInfo": {"hasNextPage": false}}}}}`,
+		}},
+	})
+	assert.NoError(t, err)
+
+	// Sub-issues past the first page are fetched; #4 is at the depth limit
+	root := &IssueNode{ID: "A", Number: 1, Repository: "owner/repo", subIssueCount: 3}
+	assert.NoError(t, fetchSubTree(client, root, 1, 1))
+	assert.Equal(t, []int{2, 3, 4}, numbers(root.Children))
+	assert.Equal(t, []string{"#4 has 1 sub-issues below the depth limit"}, root.missingSubIssues())
+}
@@ -0,0 +1,318 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// wizardPrompter is the subset of the go-gh prompter used by the create wizard
+type wizardPrompter interface {
+	Select(prompt, defaultValue string, options []string) (int, error)
+	MultiSelect(prompt string, defaultValues, options []string) ([]int, error)
+	Input(prompt, defaultValue string) (string, error)
+	Confirm(prompt string, defaultValue bool) (bool, error)
+}
+
+// wizardSource provides the choices offered by the create wizard
+type wizardSource interface {
+	RecentIssues() ([]IssueInfo, error)
+	Labels() ([]string, error)
+	AssignableUsers() ([]string, error)
+	Milestones() ([]string, error)
+	Projects() ([]string, error)
+}
+
+// createAnswers holds the values collected by the create wizard
+type createAnswers struct {
+	Parent    string
+	Title     string
+	Body      string
+	Labels    []string
+	Assignees []string
+	Milestone string
+	Projects  []string
+}
+
+// errWizardCancelled is returned when the user cancels the create wizard
+var errWizardCancelled = errors.New("creation cancelled")
+
+const otherParentOption = "Other (enter an issue number or URL)"
+
+// editBody opens the editor on the body; replaced in tests
+var editBody = func(initial string) (string, error) {
+	content, err := openEditor(initial)
+	return strings.TrimSpace(content), err
+}
+
+// runCreateWizard prompts for everything that was not given on the command line
+func runCreateWizard(p wizardPrompter, source wizardSource, answers *createAnswers) error {
+	var err error
+
+	if answers.Parent == "" {
+		answers.Parent, err = promptParent(p, source)
+		if err != nil {
+			return err
+		}
+	}
+
+	if answers.Title == "" {
+		answers.Title, err = p.Input("Title", "")
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(answers.Title) == "" {
+			return fmt.Errorf("title cannot be blank")
+		}
+	}
+
+	if answers.Body == "" {
+		write, err := p.Confirm("Write a body in your editor?", false)
+		if err != nil {
+			return err
+		}
+		if write {
+			answers.Body, err = editBody(answers.Body)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for {
+		choice, err := p.Select("What's next?", "", []string{"Submit", "Add metadata", "Cancel"})
+		if err != nil {
+			return err
+		}
+		switch choice {
+		case 0:
+			return nil
+		case 2:
+			return errWizardCancelled
+		}
+
+		if err := promptMetadata(p, source, answers); err != nil {
+			return err
+		}
+	}
+}
+
+// promptParent offers a searchable list of recent open issues
+func promptParent(p wizardPrompter, source wizardSource) (string, error) {
+	issues, err := source.RecentIssues()
+	if err != nil {
+		return "", err
+	}
+
+	options := []string{}
+	for _, issue := range issues {
+		options = append(options, fmt.Sprintf("#%d %s", issue.Number, issue.Title))
+	}
+	options = append(options, otherParentOption)
+
+	choice, err := p.Select("Parent issue", "", options)
+	if err != nil {
+		return "", err
+	}
+	if choice < len(issues) {
+		return fmt.Sprint(issues[choice].Number), nil
+	}
+
+	parent, err := p.Input("Parent issue number or URL", "")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(parent), nil
+}
+
+// promptMetadata asks which metadata to add and prompts for each of them
+func promptMetadata(p wizardPrompter, source wizardSource, answers *createAnswers) error {
+	kinds := []string{"Labels", "Assignees", "Milestone", "Projects"}
+	selected, err := p.MultiSelect("What would you like to add?", nil, kinds)
+	if err != nil {
+		return err
+	}
+
+	for _, i := range selected {
+		switch kinds[i] {
+		case "Labels":
+			answers.Labels, err = promptMultiple(p, "Labels", answers.Labels, source.Labels)
+		case "Assignees":
+			answers.Assignees, err = promptMultiple(p, "Assignees", answers.Assignees, source.AssignableUsers)
+		case "Projects":
+			answers.Projects, err = promptMultiple(p, "Projects", answers.Projects, source.Projects)
+		case "Milestone":
+			answers.Milestone, err = promptMilestone(p, answers.Milestone, source)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// promptMultiple offers a multi-select list loaded from the given data source
+func promptMultiple(p wizardPrompter, prompt string, current []string, load func() ([]string, error)) ([]string, error) {
+	options, err := load()
+	if err != nil {
+		return nil, err
+	}
+	if len(options) == 0 {
+		warnf("no %s available in this repository", strings.ToLower(prompt))
+		return current, nil
+	}
+
+	selected, err := p.MultiSelect(prompt, current, options)
+	if err != nil {
+		return nil, err
+	}
+
+	values := []string{}
+	for _, i := range selected {
+		values = append(values, options[i])
+	}
+	return values, nil
+}
+
+// promptMilestone offers the open milestones plus a "(none)" option
+func promptMilestone(p wizardPrompter, current string, source wizardSource) (string, error) {
+	milestones, err := source.Milestones()
+	if err != nil {
+		return "", err
+	}
+
+	options := append([]string{"(none)"}, milestones...)
+	choice, err := p.Select("Milestone", current, options)
+	if err != nil {
+		return "", err
+	}
+	if choice == 0 {
+		return "", nil
+	}
+	return options[choice], nil
+}
+
+// apiWizardSource loads wizard choices with the same queries used to resolve flags
+type apiWizardSource struct {
+	client *api.GraphQLClient
+	owner  string
+	repo   string
+}
+
+func (s *apiWizardSource) RecentIssues() ([]IssueInfo, error) {
+	return listRecentIssues(s.client, s.owner, s.repo, 50)
+}
+
+func (s *apiWizardSource) Labels() ([]string, error) {
+	labels, err := listLabels(s.client, s.owner, s.repo)
+	return nodeNames(labels), err
+}
+
+func (s *apiWizardSource) AssignableUsers() ([]string, error) {
+	return listAssignableUsers(s.client, s.owner, s.repo)
+}
+
+func (s *apiWizardSource) Milestones() ([]string, error) {
+	milestones, err := listMilestones(s.client, s.owner, s.repo, false)
+	return nodeNames(milestones), err
+}
+
+func (s *apiWizardSource) Projects() ([]string, error) {
+	projects, err := listProjectsV2(s.client, s.owner, s.repo)
+	var titles []string
+	for _, p := range projects {
+		titles = append(titles, p.Title)
+	}
+	return titles, err
+}
+
+// nodeNames returns the names of the nodes
+func nodeNames(nodes []NamedNode) []string {
+	var names []string
+	for _, node := range nodes {
+		names = append(names, node.Name)
+	}
+	return names
+}
+
+// listRecentIssues gets the most recently updated open issues of a repository
+func listRecentIssues(client *api.GraphQLClient, owner, repo string, limit int) ([]IssueInfo, error) {
+	query := `
+		query($owner: String!, $repo: String!, $limit: Int!) {
+			repository(owner: $owner, name: $repo) {
+				issues(first: $limit, states: OPEN, orderBy: {field: UPDATED_AT, direction: DESC}) {
+					nodes {
+						id
+						number
+						title
+						url
+						state
+					}
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"owner": owner,
+		"repo":  repo,
+		"limit": limit,
+	}
+
+	var response struct {
+		Repository struct {
+			Issues struct {
+				Nodes []IssueInfo `json:"nodes"`
+			} `json:"issues"`
+		} `json:"repository"`
+	}
+
+	err := client.Do(query, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent issues: %w", err)
+	}
+
+	return response.Repository.Issues.Nodes, nil
+}
+
+// listAssignableUsers gets the logins of users that can be assigned to issues in a repository
+func listAssignableUsers(client *api.GraphQLClient, owner, repo string) ([]string, error) {
+	query := `
+		query($owner: String!, $repo: String!) {
+			repository(owner: $owner, name: $repo) {
+				assignableUsers(first: 100) {
+					nodes {
+						login
+					}
+				}
+			}
+		}`
+
+	variables := map[string]interface{}{
+		"owner": owner,
+		"repo":  repo,
+	}
+
+	var response struct {
+		Repository struct {
+			AssignableUsers struct {
+				Nodes []struct {
+					Login string `json:"login"`
+				} `json:"nodes"`
+			} `json:"assignableUsers"`
+		} `json:"repository"`
+	}
+
+	err := client.Do(query, variables, &response)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignable users: %w", err)
+	}
+
+	var logins []string
+	for _, user := range response.Repository.AssignableUsers.Nodes {
+		logins = append(logins, user.Login)
+	}
+	return logins, nil
+}
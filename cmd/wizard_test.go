@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakePrompter answers prompts from scripted responses keyed by prompt text
+type fakePrompter struct {
+	selects      map[string][]int
+	multiSelects map[string][][]int
+	inputs       map[string][]string
+	confirms     map[string][]bool
+	asked        []string
+}
+
+func (f *fakePrompter) Select(prompt, defaultValue string, options []string) (int, error) {
+	f.asked = append(f.asked, prompt)
+	answers := f.selects[prompt]
+	if len(answers) == 0 {
+		return 0, errors.New("unexpected select: " + prompt)
+	}
+	f.selects[prompt] = answers[1:]
+	return answers[0], nil
+}
+
+func (f *fakePrompter) MultiSelect(prompt string, defaultValues, options []string) ([]int, error) {
+	f.asked = append(f.asked, prompt)
+	answers := f.multiSelects[prompt]
+	if len(answers) == 0 {
+		return nil, errors.New("unexpected multi-select: " + prompt)
+	}
+	f.multiSelects[prompt] = answers[1:]
+	return answers[0], nil
+}
+
+func (f *fakePrompter) Input(prompt, defaultValue string) (string, error) {
+	f.asked = append(f.asked, prompt)
+	answers := f.inputs[prompt]
+	if len(answers) == 0 {
+		return "", errors.New("unexpected input: " + prompt)
+	}
+	f.inputs[prompt] = answers[1:]
+	return answers[0], nil
+}
+
+func (f *fakePrompter) Confirm(prompt string, defaultValue bool) (bool, error) {
+	f.asked = append(f.asked, prompt)
+	answers := f.confirms[prompt]
+	if len(answers) == 0 {
+		return false, errors.New("unexpected confirm: " + prompt)
+	}
+	f.confirms[prompt] = answers[1:]
+	return answers[0], nil
+}
+
+type fakeWizardSource struct{}
+
+func (fakeWizardSource) RecentIssues() ([]IssueInfo, error) {
+	return []IssueInfo{{Number: 10, Title: "Epic A"}, {Number: 12, Title: "Epic B"}}, nil
+}
+func (fakeWizardSource) Labels() ([]string, error)          { return []string{"bug", "backend", "docs"}, nil }
+func (fakeWizardSource) AssignableUsers() ([]string, error) { return []string{"alice", "bob"}, nil }
+func (fakeWizardSource) Milestones() ([]string, error)      { return []string{"v1.0", "v2.0"}, nil }
+func (fakeWizardSource) Projects() ([]string, error)        { return []string{"Roadmap"}, nil }
+
+func TestRunCreateWizardFullFlow(t *testing.T) {
+	defer func(original func(string) (string, error)) { editBody = original }(editBody)
+	editBody = func(string) (string, error) { return "Body from editor", nil }
+
+	p := &fakePrompter{
+		selects: map[string][]int{
+			"Parent issue": {1},
+			"What's next?": {1, 0},
+			"Milestone":    {2},
+		},
+		multiSelects: map[string][][]int{
+			"What would you like to add?": {{0, 1, 2, 3}},
+			"Labels":                      {{0, 2}},
+			"Assignees":                   {{1}},
+			"Projects":                    {{0}},
+		},
+		inputs:   map[string][]string{"Title": {"New task"}},
+		confirms: map[string][]bool{"Write a body in your editor?": {true}},
+	}
+
+	answers := &createAnswers{}
+	err := runCreateWizard(p, fakeWizardSource{}, answers)
+	assert.NoError(t, err)
+
+	assert.Equal(t, &createAnswers{
+		Parent:    "12",
+		Title:     "New task",
+		Body:      "Body from editor",
+		Labels:    []string{"bug", "docs"},
+		Assignees: []string{"bob"},
+		Milestone: "v2.0",
+		Projects:  []string{"Roadmap"},
+	}, answers)
+}
+
+func TestRunCreateWizardOnlyAsksForMissingValues(t *testing.T) {
+	p := &fakePrompter{
+		selects:  map[string][]int{"What's next?": {0}},
+		inputs:   map[string][]string{"Title": {"Only the title"}},
+		confirms: map[string][]bool{},
+	}
+
+	answers := &createAnswers{Parent: "5", Body: "given"}
+	err := runCreateWizard(p, fakeWizardSource{}, answers)
+	assert.NoError(t, err)
+	assert.Equal(t, "5", answers.Parent)
+	assert.Equal(t, "Only the title", answers.Title)
+	assert.Equal(t, []string{"Title", "What's next?"}, p.asked)
+}
+
+func TestRunCreateWizardOtherParent(t *testing.T) {
+	p := &fakePrompter{
+		selects: map[string][]int{
+			"Parent issue": {2}, // "Other" comes after the two recent issues
+			"What's next?": {0},
+		},
+		inputs: map[string][]string{
+			"Parent issue number or URL": {" https://github.com/owner/repo/issues/99 "},
+		},
+		confirms: map[string][]bool{"Write a body in your editor?": {false}},
+	}
+
+	answers := &createAnswers{Title: "Task"}
+	assert.NoError(t, runCreateWizard(p, fakeWizardSource{}, answers))
+	assert.Equal(t, "https://github.com/owner/repo/issues/99", answers.Parent)
+}
+
+func TestRunCreateWizardCancel(t *testing.T) {
+	p := &fakePrompter{
+		selects:  map[string][]int{"What's next?": {2}},
+		confirms: map[string][]bool{"Write a body in your editor?": {false}},
+	}
+
+	err := runCreateWizard(p, fakeWizardSource{}, &createAnswers{Parent: "1", Title: "Task"})
+	assert.Equal(t, errWizardCancelled, err)
+}
+
+func TestRunCreateWizardBlankTitle(t *testing.T) {
+	p := &fakePrompter{inputs: map[string][]string{"Title": {"   "}}}
+
+	err := runCreateWizard(p, fakeWizardSource{}, &createAnswers{Parent: "1"})
+	assert.Error(t, err)
+}
+
+func TestPromptMilestoneNone(t *testing.T) {
+	p := &fakePrompter{selects: map[string][]int{"Milestone": {0}}}
+
+	milestone, err := promptMilestone(p, "v1.0", fakeWizardSource{})
+	assert.NoError(t, err)
+	assert.Equal(t, "", milestone)
+}
+
+func TestNodeNames(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, nodeNames([]NamedNode{{ID: "1", Name: "a"}, {ID: "2", Name: "b"}}))
+	assert.Nil(t, nodeNames(nil))
+}